@@ -0,0 +1,163 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Library access of users: users × libraries matrix & the users able to see an item
+// Access is derived from the users' policies (libraries, parental rating, blocked tags), as Emby does
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strings"
+)
+
+// Unrated item classes of UserPolicy.BlockUnratedItems
+const (
+	unratedMovie  UnratedItem = "Movie"
+	unratedSeries UnratedItem = "Series"
+	unratedOther  UnratedItem = "Other"
+)
+
+// Reasons an item can't be seen
+const (
+	reasonDisabled = "User disabled"
+	reasonLibrary  = "No access to library"
+	reasonRating   = "Rating above maximum"
+	reasonUnrated  = "Unrated items blocked"
+	reasonUnknown  = "Unknown rating"
+	reasonTag      = "Blocked tag"
+)
+
+// SortLibraries sorts the libraries by name, as they are displayed as columns of the matrix
+func SortLibraries(folders []VirtualFolderInfo) []string {
+	sort.SliceStable(folders, func(i, j int) bool {
+		return strings.ToLower(folders[i].Name) < strings.ToLower(folders[j].Name)
+	})
+	names := make([]string, 0, len(folders))
+	for _, f := range folders {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// GetAccessMatrixDisplayData lists the users with a column per library (sorted, see SortLibraries)
+// followed by the maximum rating & blocked tags
+func GetAccessMatrixDisplayData(users []UserDto, folders []VirtualFolderInfo, ratings []ParentalRating) []models.ListData {
+	result := make([]models.ListData, 0, len(users))
+	SortLibraries(folders)
+	sort.SliceStable(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Name) < strings.ToLower(users[j].Name)
+	})
+	for _, u := range users {
+		fields := []string{u.Name}
+		p := u.Policy
+		if p == nil {
+			p = &UserPolicy{}
+		}
+		for _, f := range folders {
+			fields = append(fields, evalFlag(!p.IsDisabled && libraryEnabled(*p, f)))
+		}
+		fields = append(fields, evalParentalRating(p.MaxParentalRating, ratings), strings.Join(p.BlockedTags, ", "))
+		result = append(result, models.ListData{Key: u.Id, Fields: fields})
+	}
+	return result
+}
+
+// GetItemAccessDisplayData lists for every user whether the item of the library given can be seen, and why not
+// Items without a rating of their own inherit the rating of their series (parentRating)
+func GetItemAccessDisplayData(d BaseItemDto, parentRating string, libraryId string, users []UserDto,
+	folders []VirtualFolderInfo, ratings []ParentalRating) []models.ListData {
+	result := make([]models.ListData, 0, len(users))
+	rating := d.OfficialRating
+	if rating == "" {
+		rating = parentRating
+	}
+	var library *VirtualFolderInfo
+	for i := range folders {
+		if folders[i].ItemId == libraryId || folders[i].Id == libraryId || folders[i].Guid == libraryId {
+			library = &folders[i]
+			break
+		}
+	}
+	tags := strings.Split(EditableValue(d, FieldTags), listSeparator)
+	sort.SliceStable(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Name) < strings.ToLower(users[j].Name)
+	})
+	for _, u := range users {
+		p := u.Policy
+		if p == nil {
+			p = &UserPolicy{}
+		}
+		var reasons string
+		if p.IsDisabled {
+			reasons = commaString(reasons, reasonDisabled)
+		}
+		if library != nil && !libraryEnabled(*p, *library) {
+			reasons = commaString(reasons, reasonLibrary)
+		}
+		if reason := ratingBlocked(d, rating, *p, ratings); reason != "" {
+			reasons = commaString(reasons, reason)
+		}
+		for _, t := range p.BlockedTags {
+			for _, tag := range tags {
+				if t != "" && strings.EqualFold(t, tag) {
+					reasons = commaString(reasons, reasonTag+" "+tag)
+				}
+			}
+		}
+		result = append(result, models.ListData{
+			Key: u.Id,
+			Fields: []string{u.Name, evalFlag(reasons == ""), rating,
+				evalParentalRating(p.MaxParentalRating, ratings), reasons},
+		})
+	}
+	return result
+}
+
+func libraryEnabled(p UserPolicy, f VirtualFolderInfo) bool {
+	if p.EnableAllFolders {
+		return true
+	}
+	for _, id := range p.EnabledFolders {
+		if id == f.Guid || id == f.ItemId || id == f.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// The reason the rating keeps the user from seeing the item, empty if it doesn't
+func ratingBlocked(d BaseItemDto, rating string, p UserPolicy, ratings []ParentalRating) string {
+	if rating == "" {
+		for _, u := range p.BlockUnratedItems {
+			if u == unratedClass(d) {
+				return reasonUnrated
+			}
+		}
+		return ""
+	}
+	if p.MaxParentalRating == 0 {
+		return ""
+	}
+	for _, r := range ratings {
+		if strings.EqualFold(r.Name, rating) {
+			if r.Value > p.MaxParentalRating {
+				return reasonRating
+			}
+			return ""
+		}
+	}
+	return reasonUnknown
+}
+
+func unratedClass(d BaseItemDto) UnratedItem {
+	switch d.Type_ {
+	case MovieType:
+		return unratedMovie
+	case SeriesType, SeasonType, EpisodeType:
+		return unratedSeries
+	default:
+		return unratedOther
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -34,6 +36,23 @@ const (
 	GETViews             = "/Users/" + substUserId + "/Views"
 	GETItems             = "/Users/" + substUserId + "/Items"
 	GETImages            = "/Items/" + substItemId + "/Images"
+	GETLiveTvChannels    = "/LiveTv/Channels"
+	GETLiveTvPrograms    = "/LiveTv/Programs"
+	GETLiveTvTimers      = "/LiveTv/Timers"
+	GETLiveTvRecordings  = "/LiveTv/Recordings"
+	GETSessions          = "/Sessions"
+	POSTPlayedItems      = "/Users/" + substUserId + "/PlayedItems/" + substItemId
+	POSTFavoriteItems    = "/Users/" + substUserId + "/FavoriteItems/" + substItemId
+	GETItem              = "/Users/" + substUserId + "/Items/" + substItemId
+	POSTItem             = "/Items/" + substItemId
+	POSTRefresh          = "/Items/" + substItemId + "/Refresh"
+	GETSystemInfo        = "/System/Info"
+	GETVirtualFolders    = "/Library/VirtualFolders"
+	POSTLibraryRefresh   = "/Library/Refresh"
+	GETScheduledTasks    = "/ScheduledTasks"
+	GETActivityLog       = "/System/ActivityLog/Entries"
+	GETUsers             = "/Users"
+	GETParentalRatings   = "/Localization/ParentalRatings"
 )
 
 // Fields for auth. request
@@ -55,13 +74,29 @@ const (
 
 // URL parameters
 const (
-	paraParentId  = "ParentId="
-	paraRecursive = "Recursive="
-	paraFields    = "Fields="
-	paraFormat    = "format="
-	paraMaxWidth  = "MaxWidth="
-	paraMaxHeight = "MaxHeight="
-	apiKey        = "api_key="
+	paraParentId     = "ParentId="
+	paraRecursive    = "Recursive="
+	paraFields       = "Fields="
+	paraFormat       = "format="
+	paraMaxWidth     = "MaxWidth="
+	paraMaxHeight    = "MaxHeight="
+	paraUserId       = "UserId="
+	paraUserData     = "EnableUserData="
+	paraCurrent      = "AddCurrentProgram="
+	paraMinEnd       = "MinEndDate="
+	paraMaxStart     = "MaxStartDate="
+	paraItemTypes    = "IncludeItemTypes="
+	paraIsMissing    = "IsMissing="
+	paraPersonIds    = "PersonIds="
+	paraMetaMode     = "MetadataRefreshMode="
+	paraImageMode    = "ImageRefreshMode="
+	paraReplMeta     = "ReplaceAllMetadata="
+	paraReplImage    = "ReplaceAllImages="
+	paraMinDate      = "MinDate="
+	paraLimit        = "Limit="
+	paraMinSaved     = "MinDateLastSaved="
+	paraMinSavedUser = "MinDateLastSavedForUser="
+	apiKey           = "api_key="
 )
 
 // Supported Emby collection types
@@ -69,9 +104,31 @@ const (
 	CollectionMovies     = "movies"
 	CollectionTVShows    = "tvshows"
 	CollectionHomeVideos = "homevideos"
+	CollectionPhotos     = "photos"
+	CollectionLiveTv     = "livetv"
 )
 
-var AllowedCollectionTypes = []string{CollectionMovies, CollectionTVShows, CollectionHomeVideos}
+// Live TV is split into pseudo collections, one per browser
+const (
+	CollectionLiveTvChannels   = CollectionLiveTv + ".channels"
+	CollectionLiveTvGuide      = CollectionLiveTv + ".guide"
+	CollectionLiveTvTimers     = CollectionLiveTv + ".timers"
+	CollectionLiveTvRecordings = CollectionLiveTv + ".recordings"
+)
+
+var AllowedCollectionTypes = []string{CollectionMovies, CollectionTVShows, CollectionHomeVideos, CollectionPhotos,
+	CollectionLiveTv}
+
+var LiveTvCollectionTypes = []string{CollectionLiveTvChannels, CollectionLiveTvGuide, CollectionLiveTvTimers,
+	CollectionLiveTvRecordings}
+
+// Hours of programme guide to fetch, starting now
+const guideHours = 12
+
+const filmographyFields = "People,ProductionYear,SeriesName,IndexNumber,IndexNumberEnd,ParentIndexNumber,Path"
+
+const missingEpisodeFields = "SeriesId,SeasonId,SeriesName,IndexNumber,IndexNumberEnd,ParentIndexNumber,PremiereDate," +
+	"LocationType"
 
 // Emby item types
 const (
@@ -81,9 +138,14 @@ const (
 	EpisodeType = "Episode"
 	MovieType   = "Movie"
 	FolderType  = "Folder"
+	PhotoType   = "Photo"
+	AlbumType   = "PhotoAlbum"
 )
 
-const statusCodeOK = 200
+const (
+	statusCodeOK        = 200
+	statusCodeNoContent = 204
+)
 
 // Body for auth. REST call
 type authBody struct {
@@ -157,6 +219,18 @@ func CreateRestUrlForPrimaryImage(endpoint string, itemid string) string {
 	return url
 }
 
+func CreateRestUrlForItem(endpoint string, itemid string) string {
+	url := CreateRestUrl(endpoint)
+	url = strings.Replace(url, substItemId, itemid, 1)
+	return url
+}
+
+func CreateRestUrlForUserItem(endpoint string, userid string, itemid string) string {
+	url := CreateRestUrlForUser(endpoint, userid)
+	url = strings.Replace(url, substItemId, itemid, 1)
+	return url
+}
+
 func FindUserIdByName(username string) (string, error) {
 	var users []UserDto
 	var response *http.Response
@@ -264,6 +338,27 @@ func UserGetViews(userid string, accesstoken string) ([]UserView, error) {
 }
 
 func UserGetItems(userid string, collectionid string, collectiontype string, accesstoken string) ([]BaseItemDto, error) {
+	return userGetItems(userid, collectionid, collectiontype, "", accesstoken)
+}
+
+// Items saved since, or whose user data changed since (e.g. played); deleted items are not returned
+func UserGetItemsSince(userid string, collectionid string, collectiontype string, since time.Time,
+	accesstoken string) ([]BaseItemDto, error) {
+	date := since.UTC().Format(time.RFC3339)
+	saved, err := userGetItems(userid, collectionid, collectiontype, paraMinSaved+date, accesstoken)
+	if err != nil {
+		return nil, err
+	}
+	played, err := userGetItems(userid, collectionid, collectiontype, paraMinSavedUser+date, accesstoken)
+	if err != nil {
+		return nil, err
+	}
+	return MergeItems(saved, played), nil
+}
+
+// filter is an additional query parameter, none if empty
+func userGetItems(userid string, collectionid string, collectiontype string, filter string,
+	accesstoken string) ([]BaseItemDto, error) {
 	var tmp QueryResultBaseItemDto
 	var result = make([]BaseItemDto, 0)
 	url := CreateRestUrlForUser(GETItems, userid)
@@ -271,6 +366,10 @@ func UserGetItems(userid string, collectionid string, collectiontype string, acc
 	url = url + "&" + paraRecursive + "true"
 	url = url + "&" + paraParentId + collectionid
 	url = url + "&" + paraFields + GetFields(collectiontype) //fields to fetch
+	url = url + "&" + paraUserData + "true"
+	if filter != "" {
+		url = url + "&" + filter
+	}
 	response, err := http.Get(url)
 	if err != nil {
 		return nil, err
@@ -301,12 +400,84 @@ func UserGetItems(userid string, collectionid string, collectiontype string, acc
 			if item.Type_ == VideoType || item.Type_ == FolderType {
 				result = append(result, item)
 			}
+		case CollectionPhotos:
+			if item.Type_ == PhotoType || item.Type_ == AlbumType || item.Type_ == FolderType {
+				result = append(result, item)
+			}
 		default:
 		}
 	}
 	return result, nil
 }
 
+// Episodes the server knows of (from the metadata provider) without a file, returned as virtual items
+func UserGetMissingEpisodes(userid string, collectionid string, accesstoken string) ([]BaseItemDto, error) {
+	var result QueryResultBaseItemDto
+	url := CreateRestUrlForUser(GETItems, userid)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraRecursive + "true"
+	url = url + "&" + paraParentId + collectionid
+	url = url + "&" + paraItemTypes + EpisodeType
+	url = url + "&" + paraIsMissing + "true"
+	url = url + "&" + paraFields + missingEpisodeFields
+	if err := getJSON(url, &result); err != nil {
+		return nil, err
+	}
+	// missing items are virtual, even if the server leaves out their location type
+	virtual := VIRTUAL_LocationType
+	for i := range result.Items {
+		result.Items[i].LocationType = &virtual
+	}
+	return result.Items, nil
+}
+
+// Movies, series & episodes (of all libraries) the person is credited for
+func UserGetItemsByPerson(userid string, personid string, accesstoken string) ([]BaseItemDto, error) {
+	var result QueryResultBaseItemDto
+	url := CreateRestUrlForUser(GETItems, userid)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraRecursive + "true"
+	url = url + "&" + paraPersonIds + personid
+	url = url + "&" + paraItemTypes + MovieType + "," + SeriesType + "," + EpisodeType + "," + VideoType
+	url = url + "&" + paraFields + filmographyFields
+	if err := getJSON(url, &result); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// A single item with all fields, as needed for an update
+func UserGetItem(userid string, itemid string, accesstoken string) (BaseItemDto, error) {
+	var result BaseItemDto
+	url := CreateRestUrlForUserItem(GETItem, userid, itemid)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	return result, err
+}
+
+// The item is replaced as a whole, so it must have been fetched by UserGetItem
+func UpdateItem(item BaseItemDto, accesstoken string) error {
+	url := CreateRestUrlForItem(POSTItem, item.Id)
+	url = url + "?" + apiKey + accesstoken
+	return sendJSON(http.MethodPost, url, item, nil)
+}
+
+// RefreshItem queues a metadata refresh, the server refreshes in the background (see RefreshCompleted)
+func RefreshItem(itemid string, mode RefreshMode, accesstoken string) error {
+	url := CreateRestUrlForItem(POSTRefresh, itemid)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraRecursive + "true"
+	refreshMode := refreshFull
+	if mode == RefreshScan {
+		refreshMode = refreshDefault
+	}
+	url = url + "&" + paraMetaMode + refreshMode
+	url = url + "&" + paraImageMode + refreshMode
+	url = url + "&" + paraReplMeta + strconv.FormatBool(mode == RefreshReplaceMetadata)
+	url = url + "&" + paraReplImage + strconv.FormatBool(mode == RefreshReplaceImages)
+	return sendJSON(http.MethodPost, url, nil, nil)
+}
+
 func GetPrimaryImageForItem(itemid string, format ImageFormat, maxwidth string, maxheight string, accesstoken string) ([]byte, error) {
 	url := CreateRestUrlForPrimaryImage(GETImages, itemid)
 	url = url + "?" + apiKey + accesstoken
@@ -334,6 +505,174 @@ func GetPrimaryImageForItem(itemid string, format ImageFormat, maxwidth string,
 	return nil, err
 }
 
+func LiveTvGetChannels(userid string, accesstoken string) ([]BaseItemDto, error) {
+	var result QueryResultBaseItemDto
+	url := CreateRestUrl(GETLiveTvChannels)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraUserId + userid
+	url = url + "&" + paraCurrent + "true"
+	url = url + "&" + paraFields + GetFields(CollectionLiveTv)
+	url = url + "&" + paraUserData + "true"
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func LiveTvGetGuide(userid string, accesstoken string) ([]BaseItemDto, error) {
+	var result QueryResultBaseItemDto
+	now := time.Now().UTC()
+	url := CreateRestUrl(GETLiveTvPrograms)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraUserId + userid
+	url = url + "&" + paraMinEnd + now.Format(time.RFC3339)
+	url = url + "&" + paraMaxStart + now.Add(guideHours*time.Hour).Format(time.RFC3339)
+	url = url + "&" + paraFields + GetFields(CollectionLiveTv)
+	url = url + "&" + paraUserData + "true"
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func LiveTvGetTimers(accesstoken string) ([]TimerInfoDto, error) {
+	var result QueryResultTimerInfoDto
+	url := CreateRestUrl(GETLiveTvTimers)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func LiveTvGetRecordings(userid string, accesstoken string) ([]BaseItemDto, error) {
+	var result QueryResultBaseItemDto
+	url := CreateRestUrl(GETLiveTvRecordings)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraUserId + userid
+	url = url + "&" + paraFields + GetFields(CollectionLiveTv)
+	url = url + "&" + paraUserData + "true"
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+func GetSessions(accesstoken string) ([]SessionInfo, error) {
+	var result []SessionInfo
+	url := CreateRestUrl(GETSessions)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func GetSystemInfo(accesstoken string) (SystemInfo, error) {
+	var result SystemInfo
+	url := CreateRestUrl(GETSystemInfo)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	return result, err
+}
+
+// The libraries with their paths, needs an administrator
+func GetVirtualFolders(accesstoken string) ([]VirtualFolderInfo, error) {
+	var result []VirtualFolderInfo
+	url := CreateRestUrl(GETVirtualFolders)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RefreshLibrary starts a scan of all libraries, a single library is scanned by RefreshItem (RefreshScan)
+func RefreshLibrary(accesstoken string) error {
+	url := CreateRestUrl(POSTLibraryRefresh)
+	url = url + "?" + apiKey + accesstoken
+	return sendJSON(http.MethodPost, url, nil, nil)
+}
+
+func GetScheduledTasks(accesstoken string) ([]TaskInfo, error) {
+	var result []TaskInfo
+	url := CreateRestUrl(GETScheduledTasks)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetActivityLog returns the newest entries (up to limit), since minDate unless zero
+func GetActivityLog(minDate time.Time, limit int, accesstoken string) (QueryResultActivityLogEntry, error) {
+	var result QueryResultActivityLogEntry
+	url := CreateRestUrl(GETActivityLog)
+	url = url + "?" + apiKey + accesstoken
+	url = url + "&" + paraLimit + strconv.Itoa(limit)
+	if !minDate.IsZero() {
+		url = url + "&" + paraMinDate + minDate.UTC().Format(time.RFC3339)
+	}
+	err := getJSON(url, &result)
+	return result, err
+}
+
+// All users with their policy & configuration, needs an administrator
+func GetUsers(accesstoken string) ([]UserDto, error) {
+	var result []UserDto
+	url := CreateRestUrl(GETUsers)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func GetParentalRatings(accesstoken string) ([]ParentalRating, error) {
+	var result []ParentalRating
+	url := CreateRestUrl(GETParentalRatings)
+	url = url + "?" + apiKey + accesstoken
+	err := getJSON(url, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UserSetPlayed marks an item as played (POST) or unplayed (DELETE), the server returns the new user data
+func UserSetPlayed(userid string, itemid string, played bool, accesstoken string) (UserItemDataDto, error) {
+	var result UserItemDataDto
+	url := CreateRestUrlForUserItem(POSTPlayedItems, userid, itemid)
+	url = url + "?" + apiKey + accesstoken
+	method := http.MethodPost
+	if !played {
+		method = http.MethodDelete
+	}
+	err := sendJSON(method, url, nil, &result)
+	return result, err
+}
+
+// UserSetFavorite adds an item to (POST) or removes it from (DELETE) the user's favourites
+func UserSetFavorite(userid string, itemid string, favorite bool, accesstoken string) (UserItemDataDto, error) {
+	var result UserItemDataDto
+	url := CreateRestUrlForUserItem(POSTFavoriteItems, userid, itemid)
+	url = url + "?" + apiKey + accesstoken
+	method := http.MethodPost
+	if !favorite {
+		method = http.MethodDelete
+	}
+	err := sendJSON(method, url, nil, &result)
+	return result, err
+}
+
 func AuthenticateUserInt() error {
 	return AuthenticateUserByCredentials(embyPreferences.EmbyUser, embyPreferences.EmbyPassword)
 }
@@ -346,10 +685,155 @@ func UserGetItenmsInt(collectionid string, collectiontype string) ([]BaseItemDto
 	return UserGetItems(EmbySession.User.Id, collectionid, collectiontype, EmbySession.AccessToken)
 }
 
+func UserGetItemsSinceInt(collectionid string, collectiontype string, since time.Time) ([]BaseItemDto, error) {
+	return UserGetItemsSince(EmbySession.User.Id, collectionid, collectiontype, since, EmbySession.AccessToken)
+}
+
+func UserGetMissingEpisodesInt(collectionid string) ([]BaseItemDto, error) {
+	return UserGetMissingEpisodes(EmbySession.User.Id, collectionid, EmbySession.AccessToken)
+}
+
+func UserGetItemsByPersonInt(personid string) ([]BaseItemDto, error) {
+	return UserGetItemsByPerson(EmbySession.User.Id, personid, EmbySession.AccessToken)
+}
+
+func UserGetItemInt(itemid string) (BaseItemDto, error) {
+	return UserGetItem(EmbySession.User.Id, itemid, EmbySession.AccessToken)
+}
+
+func UpdateItemInt(item BaseItemDto) error {
+	return UpdateItem(item, EmbySession.AccessToken)
+}
+
+func ApplyEditsInt(itemid string, values map[string]string) (BaseItemDto, error) {
+	return ApplyEdits(EmbySession.User.Id, itemid, values, EmbySession.AccessToken)
+}
+
+func RefreshItemInt(itemid string, mode RefreshMode) error {
+	return RefreshItem(itemid, mode, EmbySession.AccessToken)
+}
+
 func GetPrimaryImageForItemInt(itemid string, format ImageFormat, maxwidth string, maxheight string) ([]byte, error) {
 	return GetPrimaryImageForItem(itemid, format, maxwidth, maxheight, EmbySession.AccessToken)
 }
 
+func LiveTvGetChannelsInt() ([]BaseItemDto, error) {
+	return LiveTvGetChannels(EmbySession.User.Id, EmbySession.AccessToken)
+}
+
+func LiveTvGetGuideInt() ([]BaseItemDto, error) {
+	return LiveTvGetGuide(EmbySession.User.Id, EmbySession.AccessToken)
+}
+
+func LiveTvGetTimersInt() ([]TimerInfoDto, error) {
+	return LiveTvGetTimers(EmbySession.AccessToken)
+}
+
+func LiveTvGetRecordingsInt() ([]BaseItemDto, error) {
+	return LiveTvGetRecordings(EmbySession.User.Id, EmbySession.AccessToken)
+}
+
+func GetSessionsInt() ([]SessionInfo, error) {
+	return GetSessions(EmbySession.AccessToken)
+}
+
+func GetSystemInfoInt() (SystemInfo, error) {
+	return GetSystemInfo(EmbySession.AccessToken)
+}
+
+func GetVirtualFoldersInt() ([]VirtualFolderInfo, error) {
+	return GetVirtualFolders(EmbySession.AccessToken)
+}
+
+func RefreshLibraryInt() error {
+	return RefreshLibrary(EmbySession.AccessToken)
+}
+
+func GetScheduledTasksInt() ([]TaskInfo, error) {
+	return GetScheduledTasks(EmbySession.AccessToken)
+}
+
+func GetActivityLogInt(minDate time.Time, limit int) (QueryResultActivityLogEntry, error) {
+	return GetActivityLog(minDate, limit, EmbySession.AccessToken)
+}
+
+func GetUsersInt() ([]UserDto, error) {
+	return GetUsers(EmbySession.AccessToken)
+}
+
+func GetParentalRatingsInt() ([]ParentalRating, error) {
+	return GetParentalRatings(EmbySession.AccessToken)
+}
+
+// IsAdministratorInt is true if the user authenticated is an administrator of the server
+func IsAdministratorInt() bool {
+	return EmbySession.User != nil && EmbySession.User.Policy != nil && EmbySession.User.Policy.IsAdministrator
+}
+
+func UserSetPlayedInt(itemid string, played bool) (UserItemDataDto, error) {
+	return UserSetPlayed(EmbySession.User.Id, itemid, played, EmbySession.AccessToken)
+}
+
+func UserSetFavoriteInt(itemid string, favorite bool) (UserItemDataDto, error) {
+	return UserSetFavorite(EmbySession.User.Id, itemid, favorite, EmbySession.AccessToken)
+}
+
+// GET request, JSON response is decoded into result
+func getJSON(url string, result any) error {
+	response, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != statusCodeOK {
+		return errors.New(response.Status)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+// Request with an optional JSON body, a JSON response (if any) is decoded into result
+func sendJSON(method string, url string, body any, result any) error {
+	var content io.Reader
+	if body != nil {
+		j, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewBuffer(j)
+	}
+	clnt := &http.Client{}
+	req, err := http.NewRequest(method, url, content)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Add(contentType, contentTypeJSON)
+	}
+	response, err := clnt.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == statusCodeNoContent {
+		return nil
+	}
+	if response.StatusCode != statusCodeOK {
+		return errors.New(response.Status)
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if len(responseBody) == 0 || result == nil {
+		return nil
+	}
+	return json.Unmarshal(responseBody, result)
+}
+
 func createPair(key string, value string) string {
 	const qu = `"`
 	return key + "=" + qu + value + qu
@@ -0,0 +1,136 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Snapshot diff: items added, removed & changed between two versions of a view, changes are listed per field
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+)
+
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeChanged
+)
+
+var changeKinds = []string{"Added", "Removed", "Changed"}
+
+func (k ChangeKind) String() string {
+	return changeKinds[k]
+}
+
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type ItemChange struct {
+	Kind   ChangeKind
+	Item   BaseItemDto // the version after, the version before if removed
+	Fields []FieldChange
+}
+
+// The fields compared, as displayed in the tables
+var diffFields = []struct {
+	name  string
+	value func(d BaseItemDto) string
+}{
+	{"Title", func(d BaseItemDto) string { return d.Name }},
+	{"Original Title", func(d BaseItemDto) string { return d.OriginalTitle }},
+	{"Year", func(d BaseItemDto) string { return evalYear(d.ProductionYear) }},
+	{"Time", func(d BaseItemDto) string { return evalRuntime(d.RunTimeTicks) }},
+	{"Genre", func(d BaseItemDto) string { return evalGenres(d.Genres) }},
+	{"Studio", func(d BaseItemDto) string { return evalStudios(d.Studios) }},
+	{"Rating", func(d BaseItemDto) string { return d.OfficialRating }},
+	{"Ext.", func(d BaseItemDto) string { return d.Container }},
+	{"Codec", func(d BaseItemDto) string { return evalCodecs(d.MediaSources) }},
+	{"Resolution", func(d BaseItemDto) string { return evalResolution(d.Width, d.Height) }},
+	{"Quality", func(d BaseItemDto) string { return evalMediaInfo(d.MediaSources).Quality() }},
+	{"Audio", func(d BaseItemDto) string { return evalMediaInfo(d.MediaSources).Audio() }},
+	{"Subtitles", func(d BaseItemDto) string { return evalMediaInfo(d.MediaSources).Subtitles() }},
+	{"Path", func(d BaseItemDto) string { return d.Path }},
+}
+
+// DiffItems compares two versions of the items of a view; items are matched by id
+func DiffItems(before []BaseItemDto, after []BaseItemDto) []ItemChange {
+	result := make([]ItemChange, 0)
+	previous := make(map[string]BaseItemDto, len(before))
+	for _, d := range before {
+		previous[d.Id] = d
+	}
+	current := make(map[string]bool, len(after))
+	for _, d := range after {
+		current[d.Id] = true
+		p, ok := previous[d.Id]
+		if !ok {
+			result = append(result, ItemChange{Kind: ChangeAdded, Item: d})
+			continue
+		}
+		if fields := diffItem(p, d); len(fields) > 0 {
+			result = append(result, ItemChange{Kind: ChangeChanged, Item: d, Fields: fields})
+		}
+	}
+	for _, d := range before {
+		if !current[d.Id] {
+			result = append(result, ItemChange{Kind: ChangeRemoved, Item: d})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return ItemTitle(result[i].Item) < ItemTitle(result[j].Item)
+	})
+	return result
+}
+
+func diffItem(before BaseItemDto, after BaseItemDto) []FieldChange {
+	var result []FieldChange
+	for _, f := range diffFields {
+		b, a := f.value(before), f.value(after)
+		if b != a {
+			result = append(result, FieldChange{Field: f.name, Before: b, After: a})
+		}
+	}
+	return result
+}
+
+// DiffCounts returns the number of items added, removed & changed
+func DiffCounts(changes []ItemChange) (added int, removed int, changed int) {
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAdded:
+			added++
+		case ChangeRemoved:
+			removed++
+		default:
+			changed++
+		}
+	}
+	return added, removed, changed
+}
+
+// Changed items are parent rows, the fields changed are their children
+func GetDiffDisplayData(changes []ItemChange) []models.ListData {
+	result := make([]models.ListData, 0, len(changes))
+	for _, c := range changes {
+		row := models.ListData{
+			Key:    c.Item.Id,
+			Fields: []string{c.Kind.String(), c.Item.Type_, ItemTitle(c.Item), "", "", "", c.Item.Path},
+		}
+		for _, f := range c.Fields {
+			row.Children = append(row.Children, models.ListData{
+				Key:    c.Item.Id,
+				Fields: []string{"", "", "", f.Field, f.Before, f.After, ""},
+			})
+		}
+		result = append(result, row)
+	}
+	return result
+}
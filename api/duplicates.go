@@ -0,0 +1,197 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Duplicate finder over the items of several views
+// Items are grouped if they share a provider id, a normalized name & year, or runtime & file size
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Provider ids compared, as used as keys in ProviderIds
+var duplicateProviders = []string{"Imdb", "Tmdb"}
+
+const (
+	matchProvider = "Same "
+	matchName     = "Same name & year"
+	matchEpisode  = "Same series, season & episode"
+	matchSize     = "Same runtime & size"
+)
+
+// ViewItems are the items fetched for one view
+type ViewItems struct {
+	View  string
+	Items []BaseItemDto
+}
+
+type duplicateCandidate struct {
+	view string
+	item BaseItemDto
+}
+
+// FindDuplicates returns one parent row per group of duplicates, largest groups first
+func FindDuplicates(views []ViewItems) []models.ListData {
+	var candidates []duplicateCandidate
+	for _, v := range views {
+		for _, d := range v.Items {
+			if d.Type_ == MovieType || d.Type_ == EpisodeType || d.Type_ == VideoType {
+				candidates = append(candidates, duplicateCandidate{view: v.View, item: d})
+			}
+		}
+	}
+	// union-find, so that items matched by different criteria end up in one group
+	parent := make([]int, len(candidates))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	reasons := make(map[int]string)
+	first := make(map[string]int)
+	for i, c := range candidates {
+		for _, key := range duplicateKeys(c.item) {
+			j, ok := first[key.key]
+			if !ok {
+				first[key.key] = i
+				continue
+			}
+			reasons[i] = commaStringDistinct(reasons[i], key.reason)
+			reasons[j] = commaStringDistinct(reasons[j], key.reason)
+			parent[find(i)] = find(j)
+		}
+	}
+	groups := make(map[int][]int)
+	for i := range candidates {
+		if _, ok := reasons[i]; ok {
+			root := find(i)
+			groups[root] = append(groups[root], i)
+		}
+	}
+	result := make([]models.ListData, 0)
+	for _, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		sort.Ints(members)
+		lead := candidates[members[0]].item
+		group := models.ListData{
+			Fields: []string{lead.Name + " (" + strconv.Itoa(len(members)) + ")", evalYear(lead.ProductionYear),
+				"", "", "", "", "", "", "", ""},
+		}
+		for _, i := range members {
+			c := candidates[i]
+			group.Children = append(group.Children, duplicateRow(c, reasons[i]))
+		}
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Children) != len(result[j].Children) {
+			return len(result[i].Children) > len(result[j].Children)
+		}
+		return result[i].Fields[0] < result[j].Fields[0]
+	})
+	return result
+}
+
+type duplicateKey struct {
+	key    string
+	reason string
+}
+
+func duplicateKeys(d BaseItemDto) []duplicateKey {
+	var keys []duplicateKey
+	if d.ProviderIds != nil {
+		for _, p := range duplicateProviders {
+			if id := (*d.ProviderIds)[p]; id != "" {
+				// the item type is part of the key, movies & episodes are never compared with each other
+				keys = append(keys, duplicateKey{d.Type_ + ":" + p + ":" + id, matchProvider + p})
+			}
+		}
+	}
+	if name := normalizeName(d.Name); name != "" {
+		switch d.Type_ {
+		case EpisodeType:
+			if d.SeriesName != "" && d.IndexNumber > 0 {
+				keys = append(keys, duplicateKey{"episode:" + normalizeName(d.SeriesName) + ":" +
+					strconv.Itoa(int(d.ParentIndexNumber)) + ":" + strconv.Itoa(int(d.IndexNumber)), matchEpisode})
+			}
+		default:
+			if d.ProductionYear > 0 {
+				keys = append(keys, duplicateKey{"name:" + name + ":" + strconv.Itoa(int(d.ProductionYear)), matchName})
+			}
+		}
+	}
+	if size := evalItemSize(d); size > 0 && d.RunTimeTicks > 0 {
+		seconds := d.RunTimeTicks / 10000000
+		keys = append(keys, duplicateKey{"size:" + strconv.FormatInt(size, 10) + ":" + strconv.FormatInt(seconds, 10),
+			matchSize})
+	}
+	return keys
+}
+
+func duplicateRow(c duplicateCandidate, reason string) models.ListData {
+	d := c.item
+	name := d.Name
+	if d.Type_ == EpisodeType && d.SeriesName != "" {
+		name = d.SeriesName + " - " + d.Name
+	}
+	info := evalMediaInfo(d.MediaSources)
+	return models.ListData{
+		Key: d.Id,
+		Fields: []string{name, evalYear(d.ProductionYear), c.view, evalRuntime(d.RunTimeTicks),
+			evalResolution(d.Width, d.Height), info.Quality(), evalCodecs(d.MediaSources), evalSize(evalItemSize(d)),
+			reason, d.Path},
+	}
+}
+
+// Lower case letters & digits only, so that punctuation, spacing & leading articles don't matter
+func normalizeName(name string) string {
+	var b strings.Builder
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for _, article := range []string{"the ", "a ", "an "} {
+		lower = strings.TrimPrefix(lower, article)
+	}
+	for _, r := range lower {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func evalItemSize(d BaseItemDto) int64 {
+	if d.Size > 0 {
+		return d.Size
+	}
+	if len(d.MediaSources) > 0 {
+		return d.MediaSources[0].Size
+	}
+	return 0
+}
+
+func evalYear(year int32) string {
+	if year > 0 {
+		return strconv.Itoa(int(year))
+	}
+	return ""
+}
+
+func commaStringDistinct(source string, append string) string {
+	for _, s := range strings.Split(source, ", ") {
+		if s == append {
+			return source
+		}
+	}
+	return commaString(source, append)
+}
@@ -0,0 +1,176 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata editing: the fields that can be edited, lock checks & write-back of changed items
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Editable fields, named as in Emby's MetadataFields (as used in LockedFields)
+const (
+	FieldName           = "Name"
+	FieldOriginalTitle  = "OriginalTitle"
+	FieldSortName       = "SortName"
+	FieldProductionYear = "ProductionYear"
+	FieldGenres         = "Genres"
+	FieldTags           = "Tags"
+	FieldOfficialRating = "OfficialRating"
+	FieldOverview       = "Overview"
+)
+
+var EditableFields = []string{FieldName, FieldOriginalTitle, FieldSortName, FieldProductionYear, FieldGenres, FieldTags,
+	FieldOfficialRating, FieldOverview}
+
+const listSeparator = ", "
+
+var ErrItemLocked = errors.New("metadata is locked")
+
+// EditableValue returns the field's value as edited, lists are comma separated
+func EditableValue(d BaseItemDto, field string) string {
+	switch field {
+	case FieldName:
+		return d.Name
+	case FieldOriginalTitle:
+		return d.OriginalTitle
+	case FieldSortName:
+		if d.ForcedSortName != "" {
+			return d.ForcedSortName
+		}
+		return d.SortName
+	case FieldProductionYear:
+		return evalYear(d.ProductionYear)
+	case FieldGenres:
+		return strings.Join(d.Genres, listSeparator)
+	case FieldTags:
+		tags := make([]string, 0, len(d.TagItems))
+		for _, t := range d.TagItems {
+			tags = append(tags, t.Name)
+		}
+		if len(tags) == 0 {
+			tags = d.Tags
+		}
+		return strings.Join(tags, listSeparator)
+	case FieldOfficialRating:
+		return d.OfficialRating
+	case FieldOverview:
+		return d.Overview
+	default:
+		return ""
+	}
+}
+
+// ValidateValue checks a value before it is staged
+func ValidateValue(field string, value string) error {
+	switch field {
+	case FieldName:
+		if strings.TrimSpace(value) == "" {
+			return errors.New("the title must not be empty")
+		}
+	case FieldProductionYear:
+		if value == "" {
+			return nil
+		}
+		if year, err := strconv.Atoi(value); err != nil || year < 1800 || year > 2999 {
+			return errors.New("invalid year: " + value)
+		}
+	default:
+	}
+	return nil
+}
+
+func setEditableValue(d *BaseItemDto, field string, value string) error {
+	if err := ValidateValue(field, value); err != nil {
+		return err
+	}
+	switch field {
+	case FieldName:
+		d.Name = value
+	case FieldOriginalTitle:
+		d.OriginalTitle = value
+	case FieldSortName:
+		// the sort name is derived from the title unless forced
+		d.ForcedSortName = value
+		d.SortName = value
+	case FieldProductionYear:
+		year, _ := strconv.Atoi(value)
+		d.ProductionYear = int32(year)
+	case FieldGenres:
+		d.Genres = splitList(value)
+		d.GenreItems = nil
+		for _, g := range d.Genres {
+			d.GenreItems = append(d.GenreItems, NameLongIdPair{Name: g})
+		}
+	case FieldTags:
+		d.Tags = splitList(value)
+		d.TagItems = nil
+		for _, t := range d.Tags {
+			d.TagItems = append(d.TagItems, NameLongIdPair{Name: t})
+		}
+	case FieldOfficialRating:
+		d.OfficialRating = value
+	case FieldOverview:
+		d.Overview = value
+	default:
+		return errors.New("field not editable: " + field)
+	}
+	return nil
+}
+
+// NormalizeValue trims a value as entered, lists are reformatted, so that values compare as displayed
+func NormalizeValue(field string, value string) string {
+	switch field {
+	case FieldGenres, FieldTags:
+		return strings.Join(splitList(value), listSeparator)
+	default:
+		return strings.TrimSpace(value)
+	}
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// FieldLocked is true if the item is locked as a whole (LockData), or the field is in LockedFields
+func FieldLocked(d BaseItemDto, field string) bool {
+	if d.LockData {
+		return true
+	}
+	for _, f := range d.LockedFields {
+		if string(f) == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyEdits fetches the item, changes the fields given (field -> value) and writes the item back
+// Locks are checked against the server's copy of the item; the updated item is returned
+func ApplyEdits(userid string, itemid string, values map[string]string, accesstoken string) (BaseItemDto, error) {
+	item, err := UserGetItem(userid, itemid, accesstoken)
+	if err != nil {
+		return item, err
+	}
+	for field, value := range values {
+		if FieldLocked(item, field) {
+			return item, errors.New(item.Name + ": " + field + " " + ErrItemLocked.Error())
+		}
+		if err = setEditableValue(&item, field, value); err != nil {
+			return item, err
+		}
+	}
+	if err = UpdateItem(item, accesstoken); err != nil {
+		return item, err
+	}
+	return UserGetItem(userid, itemid, accesstoken)
+}
@@ -0,0 +1,208 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Episode check for TV series: gaps in the numbering, duplicate numbers, multi-episode files & empty seasons
+// Virtual items (LocationType "Virtual") are episodes known to the server without a file
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	issueMissing      = "Missing"
+	issueMissingKnown = "Missing (listed by server)"
+	issueDuplicate    = "Duplicate number"
+	issueRange        = "Multi-episode file"
+	issueEmptySeason  = "Season without episodes"
+	issueNoNumber     = "No episode number"
+	issueOpen         = "☐"
+)
+
+// Specials are numbered freely, so season 0 is not checked for gaps
+const specialsSeason = 0
+
+type reportSeason struct {
+	id       string
+	name     string
+	number   int32
+	episodes []BaseItemDto
+	virtual  []BaseItemDto
+}
+
+type reportSeries struct {
+	name    string
+	seasons map[string]*reportSeason
+}
+
+// GetEpisodeReport returns one parent row per series with issues, the issues are its children
+func GetEpisodeReport(dto []BaseItemDto) []models.ListData {
+	series := make(map[string]*reportSeries)
+	getSeries := func(id string, name string) *reportSeries {
+		s, ok := series[id]
+		if !ok {
+			s = &reportSeries{seasons: make(map[string]*reportSeason)}
+			series[id] = s
+		}
+		if s.name == "" {
+			s.name = name
+		}
+		return s
+	}
+	for _, d := range dto {
+		switch d.Type_ {
+		case SeriesType:
+			getSeries(d.Id, d.Name)
+		case SeasonType:
+			s := getSeries(d.SeriesId, d.SeriesName)
+			season := s.season(d.Id, d.IndexNumber)
+			season.name = d.Name
+		case EpisodeType:
+			s := getSeries(d.SeriesId, d.SeriesName)
+			season := s.season(d.SeasonId, d.ParentIndexNumber)
+			if season.name == "" {
+				season.name = d.SeasonName
+			}
+			if isVirtual(d) {
+				season.virtual = append(season.virtual, d)
+			} else {
+				season.episodes = append(season.episodes, d)
+			}
+		default:
+		}
+	}
+	result := make([]models.ListData, 0)
+	for id, s := range series {
+		issues := s.issues()
+		if len(issues) == 0 {
+			continue
+		}
+		result = append(result, models.ListData{
+			Key:      id,
+			Fields:   []string{s.name, "", "", "", strconv.Itoa(len(issues)), "", ""},
+			Children: issues,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Fields[0] < result[j].Fields[0]
+	})
+	return result
+}
+
+// Seasons are identified by id, episodes without a season id by their season number
+func (s *reportSeries) season(id string, number int32) *reportSeason {
+	key := id
+	if key == "" {
+		key = "#" + strconv.Itoa(int(number))
+	}
+	season, ok := s.seasons[key]
+	if !ok {
+		season = &reportSeason{id: id, number: number}
+		s.seasons[key] = season
+	}
+	return season
+}
+
+func (s *reportSeries) issues() []models.ListData {
+	seasons := make([]*reportSeason, 0, len(s.seasons))
+	for _, season := range s.seasons {
+		seasons = append(seasons, season)
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[i].number < seasons[j].number
+	})
+	result := make([]models.ListData, 0)
+	for _, season := range seasons {
+		result = append(result, season.issues(s.name)...)
+	}
+	return result
+}
+
+func (season *reportSeason) issues(series string) []models.ListData {
+	result := make([]models.ListData, 0)
+	row := func(key string, episode string, title string, issue string, path string) {
+		result = append(result, models.ListData{
+			Key:    key,
+			Fields: []string{series, season.name, episode, title, issue, path, issueOpen},
+		})
+	}
+	if len(season.episodes) == 0 {
+		row(season.id, "", "", issueEmptySeason, "")
+	}
+	sort.Slice(season.episodes, func(i, j int) bool {
+		return season.episodes[i].IndexNumber < season.episodes[j].IndexNumber
+	})
+	covered := make(map[int32][]BaseItemDto)
+	var last int32
+	for _, e := range season.episodes {
+		if e.IndexNumber <= 0 {
+			row(e.Id, "", e.Name, issueNoNumber, e.Path)
+			continue
+		}
+		first, end := episodeRange(e)
+		if end > first {
+			row(e.Id, episodeLabel(season.number, first, end), e.Name, issueRange, e.Path)
+		}
+		for n := first; n <= end; n++ {
+			covered[n] = append(covered[n], e)
+		}
+		last = max(last, end)
+	}
+	for n := int32(1); n <= last; n++ {
+		if copies := covered[n]; len(copies) > 1 {
+			for _, e := range copies {
+				row(e.Id+":"+strconv.Itoa(int(n)), episodeLabel(season.number, n, n), e.Name, issueDuplicate, e.Path)
+			}
+		}
+	}
+	// virtual items tell about missing episodes at the end of a season as well
+	known := make(map[int32]BaseItemDto)
+	now := time.Now()
+	for _, v := range season.virtual {
+		if dateOf(v.PremiereDate).After(now) {
+			continue //not aired yet
+		}
+		first, end := episodeRange(v)
+		for n := first; n <= end && n > 0; n++ {
+			known[n] = v
+			last = max(last, n)
+		}
+	}
+	for n := int32(1); n <= last; n++ {
+		if len(covered[n]) > 0 {
+			continue
+		}
+		label := episodeLabel(season.number, n, n)
+		if v, ok := known[n]; ok {
+			row(v.Id+":"+strconv.Itoa(int(n)), label, v.Name, issueMissingKnown, "")
+		} else if season.number != specialsSeason {
+			row(season.id+":"+strconv.Itoa(int(n)), label, "", issueMissing, "")
+		}
+	}
+	return result
+}
+
+func isVirtual(d BaseItemDto) bool {
+	return d.LocationType != nil && *d.LocationType == VIRTUAL_LocationType
+}
+
+func episodeRange(d BaseItemDto) (int32, int32) {
+	if d.IndexNumberEnd > d.IndexNumber {
+		return d.IndexNumber, d.IndexNumberEnd
+	}
+	return d.IndexNumber, d.IndexNumber
+}
+
+// S01E05, or S01E05-E07 for a range
+func episodeLabel(season int32, first int32, end int32) string {
+	label := fmt.Sprintf("S%02dE%02d", season, first)
+	if end > first {
+		label += fmt.Sprintf("-E%02d", end)
+	}
+	return label
+}
@@ -0,0 +1,93 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Facets of the items fetched: genres, studios, tags, years & official ratings
+// Episodes inherit the facets of their series, only items displayed as table rows are counted
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+)
+
+// GetFacets returns the facet values of every item, and the number of items per value
+// Genres, studios & tags are sorted by count, years descending, ratings by name
+func GetFacets(dto []BaseItemDto) models.Facets {
+	facets := models.Facets{
+		Counts: make([][]models.FacetCount, len(models.FacetKinds)),
+		Items:  make(map[string][]models.FacetValue),
+	}
+	for _, d := range dto {
+		facets.Items[d.Id] = itemFacets(d)
+	}
+	counts := make(map[models.FacetValue]int)
+	for _, d := range dto {
+		switch d.Type_ {
+		case SeriesType, SeasonType, FolderType, AlbumType:
+			continue
+		case EpisodeType:
+			// genres, studios & tags are mostly maintained for the series
+			values := facets.Items[d.Id]
+			for _, v := range facets.Items[d.SeriesId] {
+				if v.Kind == models.FacetGenre || v.Kind == models.FacetStudio || v.Kind == models.FacetTag {
+					values = appendFacet(values, v)
+				}
+			}
+			facets.Items[d.Id] = values
+		default:
+		}
+		for _, v := range facets.Items[d.Id] {
+			counts[v]++
+		}
+	}
+	for v, n := range counts {
+		facets.Counts[v.Kind] = append(facets.Counts[v.Kind], models.FacetCount{FacetValue: v, Count: n})
+	}
+	for kind, c := range facets.Counts {
+		sort.Slice(c, func(i, j int) bool {
+			switch models.FacetKind(kind) {
+			case models.FacetYear:
+				return c[i].Value > c[j].Value
+			case models.FacetRating:
+				return c[i].Value < c[j].Value
+			default:
+				if c[i].Count != c[j].Count {
+					return c[i].Count > c[j].Count
+				}
+				return c[i].Value < c[j].Value
+			}
+		})
+	}
+	return facets
+}
+
+func itemFacets(d BaseItemDto) []models.FacetValue {
+	var values []models.FacetValue
+	for _, g := range d.Genres {
+		values = appendFacet(values, models.FacetValue{Kind: models.FacetGenre, Value: g})
+	}
+	for _, s := range d.Studios {
+		values = appendFacet(values, models.FacetValue{Kind: models.FacetStudio, Value: s.Name})
+	}
+	for _, t := range d.TagItems {
+		values = appendFacet(values, models.FacetValue{Kind: models.FacetTag, Value: t.Name})
+	}
+	if d.ProductionYear > 0 {
+		values = append(values, models.FacetValue{Kind: models.FacetYear, Value: strconv.Itoa(int(d.ProductionYear))})
+	}
+	if d.OfficialRating != "" {
+		values = append(values, models.FacetValue{Kind: models.FacetRating, Value: d.OfficialRating})
+	}
+	return values
+}
+
+func appendFacet(values []models.FacetValue, value models.FacetValue) []models.FacetValue {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -8,18 +8,83 @@ package api
 
 import (
 	"Emby_Explorer/models"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// Default number of names per role in the table columns & the details window, see SetPeopleLimits
 const (
-	maxActors    = 5
-	maxDirectors = 2
-	maxStudios   = 1
+	maxActors     = 5
+	maxDirectors  = 2
+	maxStudios    = 1
+	maxWriters    = 2
+	maxGuestStars = 5
+	maxProducers  = 3
+	maxComposers  = 2
 )
 
+// Studios are limited like people, under this pseudo role
+const StudioRole = "Studio"
+
+// Roles with a configurable limit, in display order
+var LimitRoles = []string{string(ACTOR_PersonType), string(DIRECTOR_PersonType), string(WRITER_PersonType),
+	string(GUESTSTAR_PersonType), string(PRODUCER_PersonType), string(COMPOSER_PersonType), StudioRole}
+
+var defaultPeopleLimits = map[string]int{
+	string(ACTOR_PersonType):     maxActors,
+	string(DIRECTOR_PersonType):  maxDirectors,
+	string(WRITER_PersonType):    maxWriters,
+	string(GUESTSTAR_PersonType): maxGuestStars,
+	string(PRODUCER_PersonType):  maxProducers,
+	string(COMPOSER_PersonType):  maxComposers,
+	StudioRole:                   maxStudios,
+}
+
+var peopleLimits = defaultPeopleLimits
+
 const placeHolder = "-"
 
+const DateFormat = "2006-01-02 15:04"
+
+// Live TV categories & status
+const (
+	categoryMovie     = "Movie"
+	categorySeries    = "Series"
+	categorySports    = "Sports"
+	categoryNews      = "News"
+	categoryKids      = "Kids"
+	statusScheduled   = "Scheduled"
+	statusSeriesTimer = "Series timer"
+	statusLive        = "Live"
+	statusNew         = "New"
+	statusPremiere    = "Premiere"
+	statusRepeat      = "Repeat"
+	statusDisabled    = "Disabled"
+	statusInProgress  = "Recording"
+	statusCompleted   = "Completed"
+)
+
+// Session states
+const (
+	stateIdle    = "Idle"
+	statePlaying = "Playing"
+	statePaused  = "Paused"
+	hwSoftware   = "Software"
+)
+
+// Media stream flags & locations
+const (
+	flagDefault         = "Default"
+	flagForced          = "Forced"
+	flagHearingImpaired = "SDH"
+	flagInterlaced      = "Interlaced"
+	locationEmbedded    = "Embedded"
+	locationExternal    = "External"
+)
+
 func GetFields(collectiontype string) string {
 	var m = ""
 	switch collectiontype {
@@ -29,6 +94,10 @@ func GetFields(collectiontype string) string {
 		m = models.TVShowTableDescription.APIFields
 	case CollectionHomeVideos:
 		m = models.HomeVideoTableDescription.APIFields
+	case CollectionPhotos:
+		m = models.PhotoTableDescription.APIFields
+	case CollectionLiveTv:
+		m = models.LiveTvTableDescription.APIFields
 	default:
 	}
 	return m
@@ -43,14 +112,17 @@ func GetMovieDisplayData(dto []BaseItemDto) []models.MovieData {
 		movie.OriginalTitle = d.OriginalTitle
 		movie.ProductionYear = strconv.Itoa(int(d.ProductionYear))
 		movie.Studios = evalStudios(d.Studios)
-		movie.Actors, movie.Directors = evalPeople(d.People)
+		movie.Actors = evalPeople(d.People, ACTOR_PersonType)
+		movie.Directors = evalPeople(d.People, DIRECTOR_PersonType)
 		movie.Genres = evalGenres(d.Genres)
 		movie.Container = d.Container
 		movie.Resolution = evalResolution(d.Width, d.Height)
 		movie.Codecs = evalCodecs(d.MediaSources)
+		movie.MediaInfo = evalMediaInfo(d.MediaSources)
 		movie.Runtime = evalRuntime(d.RunTimeTicks)
 		movie.Path = d.Path
 		movie.Overview = d.Overview
+		movie.UserData = evalUserData(d.UserData, d.RunTimeTicks)
 		result = append(result, movie)
 	}
 	return result
@@ -67,7 +139,7 @@ func GetTVShowDisplayData(dto []BaseItemDto) []models.TVShowData {
 		switch d.Type_ {
 		case SeriesType:
 			item.Name = d.Name
-			item.Actors, _ = evalPeople(d.People)
+			item.Actors = evalPeople(d.People, ACTOR_PersonType)
 			item.Genres = evalGenres(d.Genres)
 			item.Studios = evalStudios(d.Studios)
 			item.Path = d.Path
@@ -88,15 +160,19 @@ func GetTVShowDisplayData(dto []BaseItemDto) []models.TVShowData {
 			item.Runtime = evalRuntime(d.RunTimeTicks)
 			item.Container = d.Container
 			item.Codecs = evalCodecs(d.MediaSources)
+			item.MediaInfo = evalMediaInfo(d.MediaSources)
 			item.Resolution = evalResolution(d.Width, d.Height)
 			item.ProductionYear = strconv.Itoa(int(d.ProductionYear))
-			item.Actors, _ = evalPeople(d.People)
+			item.Actors = evalPeople(d.People, ACTOR_PersonType)
+			item.Writers = evalPeople(d.People, WRITER_PersonType)
+			item.GuestStars = evalPeople(d.People, GUESTSTAR_PersonType)
 			item.SortIndex = d.IndexNumber
 			item.Path = d.Path
 			item.Overview = d.Overview
 			item.SeriesId = d.SeriesId
 			item.SeasonId = d.SeasonId
 			item.Type_ = d.Type_
+			item.UserData = evalUserData(d.UserData, d.RunTimeTicks)
 			episodes = append(episodes, item)
 		default:
 		}
@@ -166,9 +242,12 @@ func GetHomeVideoDisplayData(dto []BaseItemDto) []models.HomeVideoData {
 			video.Container = d.Container
 			video.Resolution = evalResolution(d.Width, d.Height)
 			video.Codecs = evalCodecs(d.MediaSources)
+			video.MediaInfo = evalMediaInfo(d.MediaSources)
 			video.Runtime = evalRuntime(d.RunTimeTicks)
 			video.Path = d.Path
 			video.ParentId = d.ParentId
+			video.VideoId = d.Id
+			video.UserData = evalUserData(d.UserData, d.RunTimeTicks)
 			videos = append(videos, video)
 		case FolderType:
 			folder = models.HomeVideoData{}
@@ -197,11 +276,362 @@ func GetHomeVideoDisplayData(dto []BaseItemDto) []models.HomeVideoData {
 	return result
 }
 
+func GetPhotoDisplayData(dto []BaseItemDto) []models.PhotoData {
+	result := make([]models.PhotoData, 0)
+	folders := make([]models.PhotoData, 0)
+	photos := make([]models.PhotoData, 0)
+	var photo, folder models.PhotoData
+	for _, d := range dto {
+		switch d.Type_ {
+		case PhotoType:
+			photo = models.PhotoData{}
+			photo.Name = d.Name
+			photo.Taken = evalDateTaken(dateOf(d.PremiereDate), dateOf(d.DateCreated))
+			if !photo.Taken.IsZero() {
+				photo.DateTaken = photo.Taken.Local().Format(DateFormat)
+			}
+			photo.CameraMake = d.CameraMake
+			photo.CameraModel = d.CameraModel
+			photo.Exposure = evalExposure(d.ExposureTime)
+			photo.FocalLength = evalFocalLength(d.FocalLength)
+			photo.Aperture = evalAperture(d.Aperture)
+			photo.IsoSpeed = evalIsoSpeed(d.IsoSpeedRating)
+			photo.Resolution = evalResolution(d.Width, d.Height)
+			photo.Orientation = evalOrientation(d.ImageOrientation)
+			photo.Latitude, photo.Longitude, photo.Altitude = evalCoordinates(d.Latitude, d.Longitude, d.Altitude)
+			photo.Path = d.Path
+			photo.PhotoId = d.Id
+			photo.ParentId = d.ParentId
+			photo.UserData = evalUserData(d.UserData, 0)
+			photos = append(photos, photo)
+		case AlbumType, FolderType:
+			folder = models.PhotoData{}
+			folder.Name = d.Name
+			folder.Path = d.Path
+			folder.FolderId = d.Id
+			folder.ParentId = d.ParentId
+			folder.IsFolder = true
+			folders = append(folders, folder)
+		default:
+		}
+	}
+	// Sort folders by Name
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].Name < folders[j].Name
+	})
+	// Sort photos by Name
+	sort.Slice(photos, func(i, j int) bool {
+		return photos[i].Name < photos[j].Name
+	})
+	names := make(map[string]string)
+	for _, f := range folders {
+		names[f.FolderId] = f.Name
+	}
+	for _, f := range folders {
+		f.Folder = names[f.ParentId]
+		result = append(result, f)
+	}
+	// Photos stored directly in the library root have no folder
+	for _, p := range photos {
+		p.Folder = names[p.ParentId]
+		result = append(result, p)
+	}
+	return result
+}
+
+func GetLiveTvChannelDisplayData(dto []BaseItemDto) []models.LiveTvData {
+	result := make([]models.LiveTvData, 0)
+	var channel models.LiveTvData
+	for _, d := range dto {
+		channel = models.LiveTvData{}
+		channel.Number = evalChannelNumber(d)
+		channel.Channel = d.Name
+		channel.ChannelId = d.Id
+		channel.ItemId = d.Id
+		if d.Disabled {
+			channel.Status = statusDisabled
+		}
+		channel.UserData = evalUserData(d.UserData, 0)
+		if p := d.CurrentProgram; p != nil {
+			channel.Title = p.Name
+			channel.EpisodeTitle = p.EpisodeTitle
+			channel.Start, channel.End = evalAiring(dateOf(p.StartDate), dateOf(p.EndDate))
+			channel.Runtime = evalRuntime(p.RunTimeTicks)
+			channel.Category = evalProgramCategory(*p)
+			channel.Overview = p.Overview
+			channel.ItemId = p.Id
+		}
+		result = append(result, channel)
+	}
+	sortByChannel(result)
+	return result
+}
+
+func GetLiveTvGuideDisplayData(dto []BaseItemDto) []models.LiveTvData {
+	result := make([]models.LiveTvData, 0)
+	var program models.LiveTvData
+	for _, d := range dto {
+		program = models.LiveTvData{}
+		program.Number = evalChannelNumber(d)
+		program.Channel = d.ChannelName
+		program.ChannelId = d.ChannelId
+		program.Title = d.Name
+		program.EpisodeTitle = d.EpisodeTitle
+		program.Start, program.End = evalAiring(dateOf(d.StartDate), dateOf(d.EndDate))
+		program.Runtime = evalRuntime(d.RunTimeTicks)
+		program.Category = evalProgramCategory(d)
+		program.Status = evalProgramStatus(d)
+		program.Overview = d.Overview
+		program.ItemId = d.Id
+		program.UserData = evalUserData(d.UserData, d.RunTimeTicks)
+		result = append(result, program)
+	}
+	sortByChannel(result)
+	return result
+}
+
+func GetLiveTvTimerDisplayData(timers []TimerInfoDto) []models.LiveTvData {
+	result := make([]models.LiveTvData, 0)
+	var timer models.LiveTvData
+	for _, t := range timers {
+		timer = models.LiveTvData{}
+		timer.Channel = t.ChannelName
+		timer.ChannelId = t.ChannelId
+		timer.Title = t.Name
+		timer.Start, timer.End = evalAiring(t.StartDate, t.EndDate)
+		timer.Runtime = evalRuntime(t.RunTimeTicks)
+		timer.Overview = t.Overview
+		timer.ItemId = t.ProgramId
+		if t.Status != nil {
+			timer.Status = string(*t.Status)
+		}
+		if t.SeriesTimerId != "" {
+			timer.Status = commaString(timer.Status, statusSeriesTimer)
+		}
+		if p := t.ProgramInfo; p != nil {
+			timer.Number = evalChannelNumber(*p)
+			timer.EpisodeTitle = p.EpisodeTitle
+			timer.Category = evalProgramCategory(*p)
+		}
+		result = append(result, timer)
+	}
+	// Sort timers by start date
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start < result[j].Start
+	})
+	return result
+}
+
+func GetLiveTvRecordingDisplayData(dto []BaseItemDto) []models.LiveTvData {
+	result := make([]models.LiveTvData, 0)
+	var recording models.LiveTvData
+	for _, d := range dto {
+		recording = models.LiveTvData{}
+		recording.Number = evalChannelNumber(d)
+		recording.Channel = d.ChannelName
+		recording.ChannelId = d.ChannelId
+		recording.Title = d.Name
+		if d.SeriesName != "" {
+			recording.Title = d.SeriesName
+		}
+		recording.EpisodeTitle = d.EpisodeTitle
+		recording.Start, recording.End = evalAiring(dateOf(d.StartDate), dateOf(d.EndDate))
+		if premiere := dateOf(d.PremiereDate); recording.Start == "" && !premiere.IsZero() {
+			recording.Start = premiere.Local().Format(DateFormat)
+		}
+		recording.Runtime = evalRuntime(d.RunTimeTicks)
+		recording.Category = evalProgramCategory(d)
+		recording.Status = evalRecordingStatus(d)
+		recording.Path = d.Path
+		recording.Overview = d.Overview
+		recording.ItemId = d.Id
+		recording.UserData = evalUserData(d.UserData, d.RunTimeTicks)
+		result = append(result, recording)
+	}
+	// Sort recordings by title and date
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Title == result[j].Title {
+			return result[i].Start < result[j].Start
+		}
+		return result[i].Title < result[j].Title
+	})
+	return result
+}
+
+// GetSessionDisplayData lists sessions with an item playing first, then by last activity
+func GetSessionDisplayData(sessions []SessionInfo) []models.ListData {
+	result := make([]models.ListData, 0)
+	sort.SliceStable(sessions, func(i, j int) bool {
+		pi, pj := sessions[i].NowPlayingItem != nil, sessions[j].NowPlayingItem != nil
+		if pi != pj {
+			return pi
+		}
+		return sessions[i].LastActivityDate.After(sessions[j].LastActivityDate)
+	})
+	for _, s := range sessions {
+		var nowPlaying, progress, method, reasons, video, audio, bitrate, hardware, cpu string
+		state := stateIdle
+		user := s.UserName
+		for _, u := range s.AdditionalUsers {
+			user = commaString(user, u.UserName)
+		}
+		if item := s.NowPlayingItem; item != nil {
+			nowPlaying = item.Name
+			if item.SeriesName != "" {
+				nowPlaying = item.SeriesName + " - " + item.Name
+			}
+			state = statePlaying
+			if p := s.PlayState; p != nil {
+				if p.IsPaused {
+					state = statePaused
+				}
+				if p.PlayMethod != nil {
+					method = string(*p.PlayMethod)
+				}
+				if item.RunTimeTicks > 0 {
+					progress = strconv.Itoa(int(p.PositionTicks*100/item.RunTimeTicks)) + "%"
+				}
+			}
+			video, audio = evalSourceCodecs(*item)
+			if len(item.MediaSources) > 0 {
+				bitrate = evalBitrate(item.MediaSources[0].Bitrate)
+			}
+		}
+		if t := s.TranscodingInfo; t != nil {
+			for _, r := range t.TranscodeReasons {
+				reasons = commaString(reasons, string(r))
+			}
+			if !t.IsVideoDirect && t.VideoCodec != "" {
+				video = evalTranscode(video, t.VideoCodec+" "+evalResolution(t.Width, t.Height))
+				hardware = evalHardware(t)
+			}
+			if !t.IsAudioDirect && t.AudioCodec != "" {
+				audio = evalTranscode(audio, t.AudioCodec)
+			}
+			if t.Bitrate > 0 {
+				bitrate = evalBitrate(t.Bitrate)
+			}
+			if t.CurrentCpuUsage > 0 {
+				cpu = strconv.Itoa(int(math.Round(t.CurrentCpuUsage))) + "%"
+			}
+		}
+		var lastActivity = ""
+		if !s.LastActivityDate.IsZero() {
+			lastActivity = s.LastActivityDate.Local().Format(DateFormat)
+		}
+		client := s.Client
+		if s.ApplicationVersion != "" {
+			client = client + " " + s.ApplicationVersion
+		}
+		result = append(result, models.ListData{
+			Key: s.Id,
+			Fields: []string{user, client, s.DeviceName, nowPlaying, progress, state, method, reasons, video, audio,
+				bitrate, hardware, cpu, s.RemoteEndPoint, lastActivity},
+		})
+	}
+	return result
+}
+
+// GetMediaSourceDisplayData lists every media source (version) of an item, each with all of its streams
+func GetMediaSourceDisplayData(d BaseItemDto) []models.ListData {
+	result := make([]models.ListData, 0)
+	for _, m := range d.MediaSources {
+		source := models.ListData{Key: m.Id}
+		name := m.Name
+		if name == "" {
+			name = d.Name
+		}
+		if m.Size > 0 {
+			name = name + " (" + evalSize(m.Size) + ")"
+		}
+		source.Fields = []string{name, m.Container, "", "", "", "", "", "", "", "", evalBitrate(m.Bitrate), "", "",
+			"", m.Path}
+		for _, s := range m.MediaStreams {
+			source.Children = append(source.Children, evalMediaStream(s))
+		}
+		result = append(result, source)
+	}
+	return result
+}
+
+func evalMediaStream(s MediaStream) models.ListData {
+	var kind, level, depth, frameRate, channels, flags, location = "", "", "", "", "", "", locationEmbedded
+	if s.Type_ != nil {
+		kind = string(*s.Type_)
+	}
+	if s.Level > 0 {
+		level = strconv.FormatFloat(s.Level, 'f', -1, 64)
+	}
+	if s.BitDepth > 0 {
+		depth = strconv.Itoa(int(s.BitDepth)) + " bit"
+	}
+	if s.RealFrameRate > 0 {
+		frameRate = strconv.FormatFloat(float64(s.RealFrameRate), 'f', 3, 32)
+	} else if s.AverageFrameRate > 0 {
+		frameRate = strconv.FormatFloat(float64(s.AverageFrameRate), 'f', 3, 32)
+	}
+	if s.Channels > 0 {
+		channels = strconv.Itoa(int(s.Channels))
+	}
+	if s.IsDefault {
+		flags = commaString(flags, flagDefault)
+	}
+	if s.IsForced {
+		flags = commaString(flags, flagForced)
+	}
+	if s.IsHearingImpaired {
+		flags = commaString(flags, flagHearingImpaired)
+	}
+	if s.IsInterlaced {
+		flags = commaString(flags, flagInterlaced)
+	}
+	if s.IsExternal {
+		location = locationExternal
+	}
+	language := s.DisplayLanguage
+	if language == "" {
+		language = s.Language
+	}
+	title := s.DisplayTitle
+	if s.IsExternal && s.Path != "" {
+		title = s.Path
+	}
+	return models.ListData{
+		Fields: []string{"#" + strconv.Itoa(int(s.Index)) + " " + kind, s.Codec, s.Profile, level, depth,
+			evalVideoRange(s), evalResolution(s.Width, s.Height), frameRate, channels, s.ChannelLayout,
+			evalBitrate(s.BitRate), language, flags, location, title},
+	}
+}
+
+// SDR/HDR as reported by the server, refined by the extended type, e.g. "HDR10" or "Dolby Vision Profile 8.1"
+func evalVideoRange(s MediaStream) string {
+	if s.Type_ == nil || *s.Type_ != VIDEO_MediaStreamType {
+		return ""
+	}
+	if s.ExtendedVideoSubTypeDescription != "" && s.ExtendedVideoSubTypeDescription != "None" {
+		return s.ExtendedVideoSubTypeDescription
+	}
+	return s.VideoRange
+}
+
+func evalSize(bytes int64) string {
+	const unit = 1024
+	if bytes <= 0 {
+		return ""
+	}
+	if bytes < unit*unit {
+		return strconv.FormatFloat(float64(bytes)/unit, 'f', 1, 64) + " KB"
+	}
+	if bytes < unit*unit*unit {
+		return strconv.FormatFloat(float64(bytes)/(unit*unit), 'f', 1, 64) + " MB"
+	}
+	return strconv.FormatFloat(float64(bytes)/(unit*unit*unit), 'f', 2, 64) + " GB"
+}
+
 func evalStudios(studios []NameLongIdPair) string {
 	var s = ""
 	for i, studio := range studios {
-		i++
-		if i > maxStudios {
+		if i >= peopleLimits[StudioRole] {
 			break
 		}
 		s = commaString(s, studio.Name)
@@ -209,27 +639,57 @@ func evalStudios(studios []NameLongIdPair) string {
 	return s
 }
 
-func evalPeople(people []BaseItemPerson) (string, string) {
-	var actors, directors = "", ""
-	var countactors, countdirectors = 0, 0
+// Names of the given role, up to the role's limit
+func evalPeople(people []BaseItemPerson, role PersonType) string {
+	var names = ""
+	var count = 0
 	for _, p := range people {
-		if *p.Type_ == ACTOR_PersonType {
-			countactors++
-			if countactors <= maxActors {
-				actors = commaString(actors, p.Name)
-			}
+		if count >= peopleLimits[string(role)] {
+			break
 		}
-		if *p.Type_ == DIRECTOR_PersonType {
-			countdirectors++
-			if countdirectors <= maxDirectors {
-				directors = commaString(directors, p.Name)
-			}
+		if p.Type_ != nil && *p.Type_ == role {
+			count++
+			names = commaString(names, p.Name)
 		}
-		if countactors > maxActors && countdirectors > maxDirectors {
-			break
+	}
+	return names
+}
+
+// GetPeople returns the names of the role, up to the role's limit; for roles without a table column
+// (producers & composers), displayed in the details window
+func GetPeople(d BaseItemDto, role PersonType) string {
+	return evalPeople(d.People, role)
+}
+
+// SetPeopleLimits sets the number of names per role (see LimitRoles), roles not given keep their default
+func SetPeopleLimits(limits map[string]int) {
+	peopleLimits = make(map[string]int)
+	for role, limit := range defaultPeopleLimits {
+		peopleLimits[role] = limit
+		if l, ok := limits[role]; ok && l >= 0 {
+			peopleLimits[role] = l
 		}
 	}
-	return actors, directors
+}
+
+func GetPeopleLimit(role string) int {
+	return peopleLimits[role]
+}
+
+// GetCastDisplayData lists all people of an item with their role, in the order delivered by the server
+func GetCastDisplayData(d BaseItemDto) []models.ListData {
+	result := make([]models.ListData, 0)
+	for _, p := range d.People {
+		role := ""
+		if p.Type_ != nil {
+			role = string(*p.Type_)
+		}
+		result = append(result, models.ListData{
+			Key:    p.Id,
+			Fields: []string{p.Name, role, p.Role},
+		})
+	}
+	return result
 }
 
 func evalGenres(genres []string) string {
@@ -279,6 +739,93 @@ func evalCodecs(media []MediaSourceInfo) string {
 	return codecs
 }
 
+// Languages of all audio & subtitle streams in all versions, as ISO 639-2/B codes (see normalizeLanguage)
+func evalMediaInfo(media []MediaSourceInfo) models.MediaInfo {
+	var info models.MediaInfo
+	videoFound := false
+	for _, m := range media {
+		for _, s := range m.MediaStreams {
+			if s.Type_ == nil {
+				continue
+			}
+			language := normalizeLanguage(s.Language, s.DisplayLanguage)
+			switch *s.Type_ {
+			case VIDEO_MediaStreamType:
+				// the first video stream of the first version decides, later ones may be e.g. cover art
+				if !videoFound {
+					videoFound = true
+					info.ResolutionClass = evalResolutionClass(s.Width, s.Height)
+					info.DynamicRange = evalDynamicRange(s)
+					info.BitDepth = s.BitDepth
+				}
+			case AUDIO_MediaStreamType:
+				info.AudioLanguages = appendDistinct(info.AudioLanguages, language)
+			case SUBTITLE_MediaStreamType:
+				info.SubtitleLanguages = appendDistinct(info.SubtitleLanguages, language)
+			default:
+			}
+		}
+	}
+	return info
+}
+
+// Cropped (e.g. scope) videos are classified by width, portrait videos by height
+func evalResolutionClass(w int32, h int32) string {
+	switch {
+	case w <= 0 || h <= 0:
+		return ""
+	case w >= 3200 || h >= 2000:
+		return models.ResolutionClasses[3]
+	case w >= 1800 || h >= 1000:
+		return models.ResolutionClasses[2]
+	case w >= 1200 || h >= 700:
+		return models.ResolutionClasses[1]
+	default:
+		return models.ResolutionClasses[0]
+	}
+}
+
+// Extended type first (set by the server for DV & HDR10+), then the transfer characteristics
+func evalDynamicRange(s MediaStream) string {
+	if s.ExtendedVideoType != nil {
+		switch strings.ToLower(string(*s.ExtendedVideoType)) {
+		case "dolbyvision":
+			return models.RangeDolbyVision
+		case "hdr10plus":
+			return models.RangeHDR10Plus
+		case "hdr10":
+			return models.RangeHDR10
+		case "hyperloggamma", "hlg":
+			return models.RangeHLG
+		default:
+		}
+	}
+	switch strings.ToLower(s.ColorTransfer) {
+	case "smpte2084":
+		return models.RangeHDR10
+	case "arib-std-b67":
+		return models.RangeHLG
+	default:
+	}
+	switch {
+	case strings.EqualFold(s.VideoRange, "HDR") || s.ColorPrimaries == "bt2020":
+		return models.RangeHDR10 // most likely, transfer characteristics unknown
+	case s.VideoRange != "" || s.ColorTransfer != "":
+		return models.RangeSDR
+	default:
+		return ""
+	}
+}
+
+func appendDistinct(list []string, value string) []string {
+	for _, l := range list {
+		if strings.EqualFold(l, value) {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
 func evalResolution(w int32, h int32) string {
 	var r = ""
 	if w > 0 && h > 0 {
@@ -287,6 +834,249 @@ func evalResolution(w int32, h int32) string {
 	return r
 }
 
+// The date the file was created if the photo has no date taken
+func evalDateTaken(taken time.Time, created time.Time) time.Time {
+	if !taken.IsZero() {
+		return taken
+	}
+	return created
+}
+
+func evalExposure(seconds float64) string {
+	var s = ""
+	if seconds > 0 {
+		if seconds < 1 {
+			s = "1/" + strconv.Itoa(int(math.Round(1/seconds))) + " s"
+		} else {
+			s = strconv.FormatFloat(seconds, 'f', -1, 64) + " s"
+		}
+	}
+	return s
+}
+
+func evalFocalLength(mm float64) string {
+	var s = ""
+	if mm > 0 {
+		s = strconv.FormatFloat(mm, 'f', -1, 64) + " mm"
+	}
+	return s
+}
+
+func evalAperture(f float64) string {
+	var s = ""
+	if f > 0 {
+		s = "f/" + strconv.FormatFloat(f, 'f', 1, 64)
+	}
+	return s
+}
+
+func evalIsoSpeed(iso int32) string {
+	var s = ""
+	if iso > 0 {
+		s = strconv.Itoa(int(iso))
+	}
+	return s
+}
+
+func evalOrientation(orientation *DrawingImageOrientation) string {
+	var s = ""
+	if orientation != nil {
+		s = string(*orientation)
+	}
+	return s
+}
+
+// Emby omits coordinates of photos without GPS data, so 0/0 means "not geotagged"
+func evalCoordinates(lat float64, lon float64, alt float64) (string, string, string) {
+	var latitude, longitude, altitude = "", "", ""
+	if lat != 0 || lon != 0 {
+		latitude = strconv.FormatFloat(lat, 'f', 6, 64)
+		longitude = strconv.FormatFloat(lon, 'f', 6, 64)
+		if alt != 0 {
+			altitude = strconv.FormatFloat(alt, 'f', 1, 64)
+		}
+	}
+	return latitude, longitude, altitude
+}
+
+// GetUserDataDisplayData maps user data returned by the user-data endpoints, runtime is unknown there
+func GetUserDataDisplayData(u UserItemDataDto) models.UserData {
+	return evalUserData(&u, 0)
+}
+
+func evalUserData(u *UserItemDataDto, ticks int64) models.UserData {
+	var data models.UserData
+	if u == nil {
+		return data
+	}
+	data.IsPlayed = u.Played
+	data.IsFavorite = u.IsFavorite
+	data.InProgress = u.PlaybackPositionTicks > 0
+	if u.Played {
+		data.Played = models.FlagYes
+	}
+	if u.IsFavorite {
+		data.Favorite = models.FlagYes
+	}
+	if u.PlayCount > 0 {
+		data.PlayCount = strconv.Itoa(int(u.PlayCount))
+	}
+	if !u.LastPlayedDate.IsZero() {
+		data.LastPlayed = u.LastPlayedDate.Local().Format(DateFormat)
+	}
+	if data.InProgress {
+		percent := u.PlayedPercentage
+		if percent == 0 && ticks > 0 {
+			percent = float64(u.PlaybackPositionTicks) * 100 / float64(ticks)
+		}
+		data.Progress = strconv.Itoa(int(percent)) + "%"
+	}
+	return data
+}
+
+func evalChannelNumber(d BaseItemDto) string {
+	if d.ChannelNumber != "" {
+		return d.ChannelNumber
+	}
+	return d.Number
+}
+
+// Dates of an item are pointers, so that dates not set are not sent back to the server as year 1 (see UpdateItem)
+func dateOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func evalAiring(start time.Time, end time.Time) (string, string) {
+	var s, e = "", ""
+	if !start.IsZero() {
+		s = start.Local().Format(DateFormat)
+	}
+	if !end.IsZero() {
+		e = end.Local().Format(DateFormat)
+	}
+	return s, e
+}
+
+func evalProgramCategory(d BaseItemDto) string {
+	var s = ""
+	if d.IsMovie {
+		s = commaString(s, categoryMovie)
+	}
+	if d.IsSeries {
+		s = commaString(s, categorySeries)
+	}
+	if d.IsSports {
+		s = commaString(s, categorySports)
+	}
+	if d.IsNews {
+		s = commaString(s, categoryNews)
+	}
+	if d.IsKids {
+		s = commaString(s, categoryKids)
+	}
+	return s
+}
+
+func evalProgramStatus(d BaseItemDto) string {
+	var s = ""
+	if d.TimerId != "" {
+		s = commaString(s, statusScheduled)
+	}
+	if d.SeriesTimerId != "" {
+		s = commaString(s, statusSeriesTimer)
+	}
+	if d.IsLive {
+		s = commaString(s, statusLive)
+	}
+	if d.IsPremiere {
+		s = commaString(s, statusPremiere)
+	} else if d.IsNew {
+		s = commaString(s, statusNew)
+	}
+	if d.IsRepeat {
+		s = commaString(s, statusRepeat)
+	}
+	return s
+}
+
+func evalRecordingStatus(d BaseItemDto) string {
+	// A recording still in progress reports its completion percentage
+	if d.CompletionPercentage > 0 && d.CompletionPercentage < 100 {
+		return statusInProgress + " " + strconv.Itoa(int(d.CompletionPercentage)) + "%"
+	}
+	if d.Path == "" {
+		return placeHolder
+	}
+	return statusCompleted
+}
+
+// Sort by channel number (numerically where possible), then start date
+func sortByChannel(data []models.LiveTvData) {
+	sort.SliceStable(data, func(i, j int) bool {
+		if data[i].Number != data[j].Number {
+			ni, erri := strconv.ParseFloat(data[i].Number, 64)
+			nj, errj := strconv.ParseFloat(data[j].Number, 64)
+			if erri == nil && errj == nil {
+				return ni < nj
+			}
+			return data[i].Number < data[j].Number
+		}
+		if data[i].Channel != data[j].Channel {
+			return data[i].Channel < data[j].Channel
+		}
+		return data[i].Start < data[j].Start
+	})
+}
+
+// Video & audio codec of the first media source, as stored on the server
+func evalSourceCodecs(d BaseItemDto) (string, string) {
+	var video, audio = "", ""
+	streams := d.MediaStreams
+	if len(d.MediaSources) > 0 {
+		streams = d.MediaSources[0].MediaStreams
+	}
+	for _, s := range streams {
+		if s.Type_ == nil {
+			continue
+		}
+		if *s.Type_ == VIDEO_MediaStreamType && video == "" {
+			video = s.Codec
+		}
+		if *s.Type_ == AUDIO_MediaStreamType && audio == "" {
+			audio = s.Codec
+		}
+	}
+	return video, audio
+}
+
+func evalTranscode(source string, target string) string {
+	if source == "" {
+		source = placeHolder
+	}
+	return source + " > " + target
+}
+
+func evalBitrate(bps int32) string {
+	if bps <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(bps)/1000000, 'f', 1, 64) + " Mbps"
+}
+
+func evalHardware(t *TranscodingInfo) string {
+	var decoder, encoder = hwSoftware, hwSoftware
+	if t.VideoDecoderIsHardware {
+		decoder = t.VideoDecoderHwAccel
+	}
+	if t.VideoEncoderIsHardware {
+		encoder = t.VideoEncoderHwAccel
+	}
+	return decoder + " / " + encoder
+}
+
 func commaString(source string, append string) string {
 	s := source
 	if s != "" {
@@ -0,0 +1,106 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Health checks: metadata & media rules run over the items fetched
+// Every rule applies to some item types only, disabled rules are skipped
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import "Emby_Explorer/models"
+
+// Below 1280x720 is considered low resolution
+const (
+	lowResolutionWidth  = 1280
+	lowResolutionHeight = 720
+)
+
+type healthCheck struct {
+	rule   models.HealthRule
+	types  []string
+	failed func(d BaseItemDto) bool
+}
+
+var videoTypes = []string{MovieType, EpisodeType, VideoType}
+
+var healthChecks = []healthCheck{
+	{models.HealthRule{Id: "image", Name: "No primary image", Severity: models.SeverityWarning},
+		[]string{MovieType, SeriesType, SeasonType, EpisodeType, VideoType},
+		func(d BaseItemDto) bool { return d.ImageTags == nil || (*d.ImageTags)[string(PRIMARY_ImageType)] == "" }},
+	{models.HealthRule{Id: "overview", Name: "Empty overview", Severity: models.SeverityInfo},
+		[]string{MovieType, SeriesType, EpisodeType},
+		func(d BaseItemDto) bool { return d.Overview == "" }},
+	{models.HealthRule{Id: "year", Name: "Missing production year", Severity: models.SeverityWarning},
+		[]string{MovieType, SeriesType},
+		func(d BaseItemDto) bool { return d.ProductionYear <= 0 }},
+	{models.HealthRule{Id: "providers", Name: "No provider ids", Severity: models.SeverityError},
+		[]string{MovieType, SeriesType},
+		func(d BaseItemDto) bool { return d.ProviderIds == nil || len(*d.ProviderIds) == 0 }},
+	{models.HealthRule{Id: "resolution", Name: "Low resolution", Severity: models.SeverityInfo},
+		videoTypes,
+		func(d BaseItemDto) bool {
+			return d.Width > 0 && d.Height > 0 && d.Width < lowResolutionWidth && d.Height < lowResolutionHeight
+		}},
+	{models.HealthRule{Id: "container", Name: "Unknown container", Severity: models.SeverityWarning},
+		videoTypes,
+		func(d BaseItemDto) bool { return d.Container == "" }},
+	{models.HealthRule{Id: "runtime", Name: "No runtime", Severity: models.SeverityWarning},
+		videoTypes,
+		func(d BaseItemDto) bool { return d.RunTimeTicks <= 0 }},
+	{models.HealthRule{Id: "locked", Name: "Metadata locked", Severity: models.SeverityInfo},
+		[]string{MovieType, SeriesType, SeasonType, EpisodeType, VideoType},
+		func(d BaseItemDto) bool { return d.LockData }},
+}
+
+// HealthRules returns all rules, in the order they are checked
+func HealthRules() []models.HealthRule {
+	result := make([]models.HealthRule, 0, len(healthChecks))
+	for _, c := range healthChecks {
+		result = append(result, c.rule)
+	}
+	return result
+}
+
+// CheckHealth runs the enabled rules over the items, virtual items (without a file) are skipped
+func CheckHealth(dto []BaseItemDto, disabled map[string]bool) []models.HealthIssue {
+	result := make([]models.HealthIssue, 0)
+	for _, d := range dto {
+		if isVirtual(d) {
+			continue
+		}
+		for _, c := range healthChecks {
+			if disabled[c.rule.Id] || !c.appliesTo(d.Type_) || !c.failed(d) {
+				continue
+			}
+			result = append(result, models.HealthIssue{
+				Rule:   c.rule,
+				ItemId: d.Id,
+				Title:  ItemTitle(d),
+				Type_:  d.Type_,
+				Year:   evalYear(d.ProductionYear),
+				Path:   d.Path,
+			})
+		}
+	}
+	return result
+}
+
+func (c healthCheck) appliesTo(itemType string) bool {
+	for _, t := range c.types {
+		if t == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemTitle is the name of the item, with series (and episode number) for seasons & episodes
+func ItemTitle(d BaseItemDto) string {
+	switch d.Type_ {
+	case SeasonType:
+		return d.SeriesName + " - " + d.Name
+	case EpisodeType:
+		return d.SeriesName + " - " + episodeLabel(d.ParentIndexNumber, d.IndexNumber, d.IndexNumberEnd) + " - " + d.Name
+	default:
+		return d.Name
+	}
+}
@@ -0,0 +1,97 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Stream languages, normalized to lower case ISO 639-2/B codes, so that e.g. "deu", "de", "ger" & "German" match
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import "strings"
+
+const languageUndefined = "und"
+
+// ISO 639-2/B code, 639-2/T code (if different), 639-1 code & English name of the languages commonly found
+var languageCodes = []struct {
+	bibliographic string
+	terminology   string
+	alpha2        string
+	name          string
+}{
+	{"alb", "sqi", "sq", "albanian"},
+	{"ara", "", "ar", "arabic"},
+	{"arm", "hye", "hy", "armenian"},
+	{"baq", "eus", "eu", "basque"},
+	{"bul", "", "bg", "bulgarian"},
+	{"bur", "mya", "my", "burmese"},
+	{"cat", "", "ca", "catalan"},
+	{"chi", "zho", "zh", "chinese"},
+	{"cze", "ces", "cs", "czech"},
+	{"dan", "", "da", "danish"},
+	{"dut", "nld", "nl", "dutch"},
+	{"eng", "", "en", "english"},
+	{"est", "", "et", "estonian"},
+	{"fin", "", "fi", "finnish"},
+	{"fre", "fra", "fr", "french"},
+	{"geo", "kat", "ka", "georgian"},
+	{"ger", "deu", "de", "german"},
+	{"gre", "ell", "el", "greek"},
+	{"heb", "", "he", "hebrew"},
+	{"hin", "", "hi", "hindi"},
+	{"hrv", "", "hr", "croatian"},
+	{"hun", "", "hu", "hungarian"},
+	{"ice", "isl", "is", "icelandic"},
+	{"ind", "", "id", "indonesian"},
+	{"ita", "", "it", "italian"},
+	{"jpn", "", "ja", "japanese"},
+	{"kor", "", "ko", "korean"},
+	{"lav", "", "lv", "latvian"},
+	{"lit", "", "lt", "lithuanian"},
+	{"mac", "mkd", "mk", "macedonian"},
+	{"mao", "mri", "mi", "maori"},
+	{"may", "msa", "ms", "malay"},
+	{"nor", "", "no", "norwegian"},
+	{"per", "fas", "fa", "persian"},
+	{"pol", "", "pl", "polish"},
+	{"por", "", "pt", "portuguese"},
+	{"rum", "ron", "ro", "romanian"},
+	{"rus", "", "ru", "russian"},
+	{"slo", "slk", "sk", "slovak"},
+	{"slv", "", "sl", "slovenian"},
+	{"spa", "", "es", "spanish"},
+	{"srp", "", "sr", "serbian"},
+	{"swe", "", "sv", "swedish"},
+	{"tha", "", "th", "thai"},
+	{"tib", "bod", "bo", "tibetan"},
+	{"tur", "", "tr", "turkish"},
+	{"ukr", "", "uk", "ukrainian"},
+	{"vie", "", "vi", "vietnamese"},
+	{"wel", "cym", "cy", "welsh"},
+}
+
+// Any code or English name -> ISO 639-2/B code
+var languageLookup = func() map[string]string {
+	lookup := make(map[string]string)
+	for _, l := range languageCodes {
+		lookup[l.bibliographic] = l.bibliographic
+		lookup[l.alpha2] = l.bibliographic
+		lookup[l.name] = l.bibliographic
+		if l.terminology != "" {
+			lookup[l.terminology] = l.bibliographic
+		}
+	}
+	return lookup
+}()
+
+// The stream's language code, the display name if there is no code; codes & names unknown are kept (lower case)
+func normalizeLanguage(language string, displayLanguage string) string {
+	l := strings.ToLower(strings.TrimSpace(language))
+	if l == "" {
+		l = strings.ToLower(strings.TrimSpace(displayLanguage))
+	}
+	if l == "" {
+		return languageUndefined
+	}
+	if code, ok := languageLookup[l]; ok {
+		return code
+	}
+	return l
+}
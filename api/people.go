@@ -0,0 +1,113 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// People browser: everybody credited in the items fetched, and the filmography of a person
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+)
+
+// Roles listed, in display order
+var browserRoles = []PersonType{ACTOR_PersonType, GUESTSTAR_PersonType, DIRECTOR_PersonType, WRITER_PersonType}
+
+var roleNames = map[PersonType]string{
+	ACTOR_PersonType:     "Actor",
+	GUESTSTAR_PersonType: "Guest star",
+	DIRECTOR_PersonType:  "Director",
+	WRITER_PersonType:    "Writer",
+}
+
+type personCredits struct {
+	name  string
+	roles map[PersonType]bool
+	items map[string]bool
+}
+
+// GetPeopleDisplayData returns one row per person (keyed by person id), most items first
+func GetPeopleDisplayData(views []ViewItems) []models.ListData {
+	people := make(map[string]*personCredits)
+	for _, v := range views {
+		for _, d := range v.Items {
+			for _, p := range d.People {
+				if p.Id == "" || p.Type_ == nil || roleNames[*p.Type_] == "" {
+					continue
+				}
+				c, ok := people[p.Id]
+				if !ok {
+					c = &personCredits{name: p.Name, roles: make(map[PersonType]bool), items: make(map[string]bool)}
+					people[p.Id] = c
+				}
+				c.roles[*p.Type_] = true
+				c.items[d.Id] = true
+			}
+		}
+	}
+	result := make([]models.ListData, 0, len(people))
+	for id, c := range people {
+		roles := ""
+		for _, r := range browserRoles {
+			if c.roles[r] {
+				roles = commaString(roles, roleNames[r])
+			}
+		}
+		result = append(result, models.ListData{
+			Key:    id,
+			Fields: []string{c.name, roles, strconv.Itoa(len(c.items))},
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ci, cj := len(people[result[i].Key].items), len(people[result[j].Key].items)
+		if ci != cj {
+			return ci > cj
+		}
+		return result[i].Fields[0] < result[j].Fields[0]
+	})
+	return result
+}
+
+// GetFilmographyDisplayData returns the items the person is credited for, with all of the person's credits
+// Items are listed once per view, newest first
+func GetFilmographyDisplayData(personId string, views []ViewItems) []models.ListData {
+	type film struct {
+		year int32
+		data models.ListData
+	}
+	films := make([]film, 0)
+	for _, v := range views {
+		for _, d := range v.Items {
+			credits := ""
+			for _, p := range d.People {
+				if p.Id != personId || p.Type_ == nil || roleNames[*p.Type_] == "" {
+					continue
+				}
+				credit := roleNames[*p.Type_]
+				if p.Role != "" {
+					credit += " (" + p.Role + ")"
+				}
+				credits = commaStringDistinct(credits, credit)
+			}
+			if credits == "" {
+				continue
+			}
+			films = append(films, film{d.ProductionYear, models.ListData{
+				Key:    d.Id,
+				Fields: []string{ItemTitle(d), d.Type_, evalYear(d.ProductionYear), credits, v.View, d.Path},
+			}})
+		}
+	}
+	sort.SliceStable(films, func(i, j int) bool {
+		if films[i].year != films[j].year {
+			return films[i].year > films[j].year
+		}
+		return films[i].data.Fields[0] < films[j].data.Fields[0]
+	})
+	result := make([]models.ListData, 0, len(films))
+	for _, f := range films {
+		result = append(result, f.data)
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata refresh: refresh options & detection of a refresh completed by the server
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"reflect"
+	"time"
+)
+
+type RefreshMode int
+
+const (
+	RefreshMissing         RefreshMode = iota // fill in missing metadata & images only
+	RefreshReplaceImages                      // download all images again
+	RefreshReplaceMetadata                    // replace all metadata, images are kept
+	RefreshScan                               // scan for new & removed files (libraries)
+)
+
+var RefreshModes = []RefreshMode{RefreshMissing, RefreshReplaceImages, RefreshReplaceMetadata}
+
+const (
+	refreshFull    = "FullRefresh"
+	refreshDefault = "Default"
+)
+
+// The server is polled until the item has been saved again, or gives up after RefreshTimeout
+const (
+	RefreshPollInterval = 3 * time.Second
+	RefreshTimeout      = 2 * time.Minute
+)
+
+// RefreshCompleted is true if the item was saved after before was fetched, or its images have changed
+func RefreshCompleted(before BaseItemDto, after BaseItemDto) bool {
+	if dateOf(after.DateLastSaved).After(dateOf(before.DateLastSaved)) {
+		return true
+	}
+	if after.Etag != before.Etag {
+		return true
+	}
+	return !reflect.DeepEqual(after.ImageTags, before.ImageTags) ||
+		!reflect.DeepEqual(after.BackdropImageTags, before.BackdropImageTags)
+}
@@ -0,0 +1,220 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Server information, libraries, scheduled tasks & the activity log
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	taskRunning     = "Running"
+	triggerDaily    = "DailyTrigger"
+	triggerWeekly   = "WeeklyTrigger"
+	triggerInterval = "IntervalTrigger"
+	triggerStartup  = "StartupTrigger"
+	atStartup       = "At startup"
+	taskCompleted   = "Completed"
+	pathSeparator   = "; "
+	progressPercent = "%"
+)
+
+// GetServerInfoDisplayData lists the properties of the server, one per row
+func GetServerInfoDisplayData(info SystemInfo) []models.ListData {
+	addresses := info.LocalAddress
+	for _, a := range info.LocalAddresses {
+		if a != info.LocalAddress {
+			addresses = commaString(addresses, a)
+		}
+	}
+	system := info.OperatingSystemDisplayName
+	if system == "" {
+		system = info.OperatingSystem
+	}
+	var state string
+	if info.HasPendingRestart {
+		state = commaString(state, "Restart pending")
+	}
+	if info.HasUpdateAvailable {
+		state = commaString(state, "Update available")
+	}
+	properties := [][]string{
+		{"Server name", info.ServerName},
+		{"Version", info.Version},
+		{"Operating system", system},
+		{"Server Id", info.Id},
+		{"Local address", addresses},
+		{"WAN address", info.WanAddress},
+		{"State", state},
+		{"Program data", info.ProgramDataPath},
+		{"Cache", info.CachePath},
+		{"Logs", info.LogPath},
+	}
+	result := make([]models.ListData, 0, len(properties))
+	for _, p := range properties {
+		if p[1] == "" {
+			continue
+		}
+		result = append(result, models.ListData{Key: p[0], Fields: p})
+	}
+	return result
+}
+
+// GetLibraryDisplayData lists the libraries by name, Key is the library's item id (as needed for a scan)
+func GetLibraryDisplayData(folders []VirtualFolderInfo) []models.ListData {
+	result := make([]models.ListData, 0, len(folders))
+	sort.SliceStable(folders, func(i, j int) bool {
+		return strings.ToLower(folders[i].Name) < strings.ToLower(folders[j].Name)
+	})
+	for _, f := range folders {
+		scan := f.RefreshStatus
+		if f.RefreshProgress > 0 {
+			scan = evalProgress(f.RefreshProgress)
+		}
+		result = append(result, models.ListData{
+			Key:    f.ItemId,
+			Fields: []string{f.Name, f.CollectionType, strings.Join(f.Locations, pathSeparator), scan},
+		})
+	}
+	return result
+}
+
+// GetTaskDisplayData lists the visible tasks, running ones first, then by category & name
+func GetTaskDisplayData(tasks []TaskInfo) []models.ListData {
+	result := make([]models.ListData, 0, len(tasks))
+	sort.SliceStable(tasks, func(i, j int) bool {
+		ri, rj := tasks[i].State == taskRunning, tasks[j].State == taskRunning
+		if ri != rj {
+			return ri
+		}
+		if tasks[i].Category != tasks[j].Category {
+			return tasks[i].Category < tasks[j].Category
+		}
+		return tasks[i].Name < tasks[j].Name
+	})
+	for _, t := range tasks {
+		if t.IsHidden {
+			continue
+		}
+		var progress, lastRun, lastResult string
+		if t.State == taskRunning {
+			progress = evalProgress(t.CurrentProgressPercentage)
+		}
+		if r := t.LastExecutionResult; r != nil {
+			if !r.EndTimeUtc.IsZero() {
+				lastRun = r.EndTimeUtc.Local().Format(DateFormat)
+			}
+			lastResult = r.Status
+			if r.Status != taskCompleted && r.ErrorMessage != "" {
+				lastResult = r.Status + ": " + r.ErrorMessage
+			}
+		}
+		result = append(result, models.ListData{
+			Key:    t.Id,
+			Fields: []string{t.Name, t.Category, t.State, progress, lastRun, lastResult, evalNextRun(t, time.Now())},
+		})
+	}
+	return result
+}
+
+// TasksRunning is true if any task is running, e.g. a library scan
+func TasksRunning(tasks []TaskInfo) bool {
+	for _, t := range tasks {
+		if t.State == taskRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// The earliest of the task's triggers; times of day are taken as local time, as the server's zone isn't known
+func evalNextRun(t TaskInfo, now time.Time) string {
+	var next time.Time
+	startup := false
+	for _, trigger := range t.Triggers {
+		var at time.Time
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		timeOfDay := time.Duration(trigger.TimeOfDayTicks * 100)
+		switch trigger.Type_ {
+		case triggerDaily:
+			at = midnight.Add(timeOfDay)
+			if at.Before(now) {
+				at = at.AddDate(0, 0, 1)
+			}
+		case triggerWeekly:
+			at = midnight.Add(timeOfDay)
+			for at.Weekday().String() != trigger.DayOfWeek || at.Before(now) {
+				at = at.AddDate(0, 0, 1)
+				if at.Sub(now) > 8*24*time.Hour {
+					at = time.Time{} // unknown day
+					break
+				}
+			}
+		case triggerInterval:
+			at = now
+			if r := t.LastExecutionResult; r != nil && !r.EndTimeUtc.IsZero() {
+				at = r.EndTimeUtc.Local().Add(time.Duration(trigger.IntervalTicks * 100))
+			}
+			if at.Before(now) {
+				at = now
+			}
+		case triggerStartup:
+			startup = true
+		default:
+		}
+		if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	if next.IsZero() {
+		if startup {
+			return atStartup
+		}
+		return ""
+	}
+	return next.Format(DateFormat)
+}
+
+func evalProgress(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', 1, 64) + progressPercent
+}
+
+// GetActivityDisplayData lists the entries of the type given (all types if empty), newest first
+func GetActivityDisplayData(entries []ActivityLogEntry, entryType string) []models.ListData {
+	result := make([]models.ListData, 0, len(entries))
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.After(entries[j].Date)
+	})
+	for _, e := range entries {
+		if entryType != "" && e.Type_ != entryType {
+			continue
+		}
+		overview := e.ShortOverview
+		if e.Overview != "" {
+			overview = commaString(overview, e.Overview)
+		}
+		result = append(result, models.ListData{
+			Key:    strconv.FormatInt(e.Id, 10),
+			Fields: []string{e.Date.Local().Format(DateFormat), e.Severity, e.Type_, e.Name, overview},
+		})
+	}
+	return result
+}
+
+// ActivityTypes returns the distinct types of the entries, sorted
+func ActivityTypes(entries []ActivityLogEntry) []string {
+	types := make([]string, 0)
+	for _, e := range entries {
+		if e.Type_ != "" {
+			types = appendDistinct(types, e.Type_)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
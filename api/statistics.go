@@ -0,0 +1,151 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Library statistics, aggregated from the items fetched for a view
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+)
+
+const (
+	statVideoCodec    = "Video codec"
+	statContainer     = "Container"
+	statResolution    = "Resolution"
+	statDynamicRange  = "HDR"
+	statChannelLayout = "Audio channels"
+	statGenre         = "Genre"
+	statStudio        = "Studio"
+	statDecade        = "Decade"
+)
+
+// GetStatistics counts playable items only (movies, episodes & videos), series, seasons & folders are skipped;
+// episodes are counted with the genres & studios of their series
+func GetStatistics(dto []BaseItemDto) models.Statistics {
+	var result models.Statistics
+	var ticks, size int64
+	titles := []string{statVideoCodec, statContainer, statResolution, statDynamicRange, statChannelLayout,
+		statGenre, statStudio, statDecade}
+	counts := make(map[string]map[string]int)
+	for _, t := range titles {
+		counts[t] = make(map[string]int)
+	}
+	series := make(map[string]BaseItemDto)
+	for _, d := range dto {
+		if d.Type_ == SeriesType {
+			series[d.Id] = d
+		}
+	}
+	for _, d := range dto {
+		if d.Type_ != MovieType && d.Type_ != EpisodeType && d.Type_ != VideoType {
+			continue
+		}
+		result.Items++
+		ticks += d.RunTimeTicks
+		for _, m := range d.MediaSources {
+			size += m.Size
+		}
+		info := evalMediaInfo(d.MediaSources)
+		video, _ := evalSourceCodecs(d)
+		counts[statVideoCodec][video]++
+		container := d.Container
+		if container == "" && len(d.MediaSources) > 0 {
+			container = d.MediaSources[0].Container
+		}
+		counts[statContainer][container]++
+		counts[statResolution][info.ResolutionClass]++
+		counts[statDynamicRange][info.DynamicRange]++
+		counts[statChannelLayout][evalChannelLayout(d)]++
+		genres, studios := itemGenresStudios(d, series)
+		for _, g := range genres {
+			counts[statGenre][g]++
+		}
+		for _, s := range studios {
+			counts[statStudio][s]++
+		}
+		decade := ""
+		if d.ProductionYear > 0 {
+			decade = strconv.Itoa(int(d.ProductionYear/10*10)) + "s"
+		}
+		counts[statDecade][decade]++
+	}
+	result.Runtime = evalTotalRuntime(ticks)
+	result.Size = evalSize(size)
+	for _, t := range titles {
+		result.Groups = append(result.Groups, models.StatisticsGroup{Title: t, Entries: sortedCounts(counts[t])})
+	}
+	return result
+}
+
+// Episodes inherit the genres & studios of their series, as the facets do (see GetFacets)
+func itemGenresStudios(d BaseItemDto, series map[string]BaseItemDto) ([]string, []string) {
+	var genres, studios []string
+	for _, g := range d.Genres {
+		genres = appendDistinct(genres, g)
+	}
+	for _, s := range d.Studios {
+		studios = appendDistinct(studios, s.Name)
+	}
+	if d.Type_ == EpisodeType {
+		if s, ok := series[d.SeriesId]; ok {
+			for _, g := range s.Genres {
+				genres = appendDistinct(genres, g)
+			}
+			for _, st := range s.Studios {
+				studios = appendDistinct(studios, st.Name)
+			}
+		}
+	}
+	return genres, studios
+}
+
+// Channel layout of the first audio stream, e.g. "5.1", falling back to the number of channels
+func evalChannelLayout(d BaseItemDto) string {
+	for _, m := range d.MediaSources {
+		for _, s := range m.MediaStreams {
+			if s.Type_ == nil || *s.Type_ != AUDIO_MediaStreamType {
+				continue
+			}
+			if s.ChannelLayout != "" {
+				return s.ChannelLayout
+			}
+			if s.Channels > 0 {
+				return strconv.Itoa(int(s.Channels)) + " ch"
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+func evalTotalRuntime(ticks int64) string {
+	if ticks <= 0 {
+		return ""
+	}
+	hours := ticks / 10000000 / 3600
+	if hours < 24 {
+		return evalRuntime(ticks)
+	}
+	return strconv.Itoa(int(hours/24)) + "d " + strconv.Itoa(int(hours%24)) + "h"
+}
+
+// Unknown values are counted as placeholder, the order is by count, then by name
+func sortedCounts(counts map[string]int) []models.StatisticsEntry {
+	result := make([]models.StatisticsEntry, 0)
+	for name, count := range counts {
+		if name == "" {
+			name = placeHolder
+		}
+		result = append(result, models.StatisticsEntry{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
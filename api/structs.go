@@ -24,6 +24,7 @@ type (
 	MetadataFields           string
 	PersonType               string
 	PlayMethod               string
+	RecordingStatus          string
 	RepeatMode               string
 	SegmentSkipMode          string
 	SubtitleDeliveryMethod   string
@@ -44,13 +45,22 @@ type TupleDoubleDouble struct {
 }
 
 const (
-	AUDIO_MediaStreamType MediaStreamType = "Audio"
-	VIDEO_MediaStreamType MediaStreamType = "Video"
+	AUDIO_MediaStreamType    MediaStreamType = "Audio"
+	VIDEO_MediaStreamType    MediaStreamType = "Video"
+	SUBTITLE_MediaStreamType MediaStreamType = "Subtitle"
 )
 
 const (
-	ACTOR_PersonType    PersonType = "Actor"
-	DIRECTOR_PersonType PersonType = "Director"
+	VIRTUAL_LocationType LocationType = "Virtual"
+)
+
+const (
+	ACTOR_PersonType     PersonType = "Actor"
+	DIRECTOR_PersonType  PersonType = "Director"
+	WRITER_PersonType    PersonType = "Writer"
+	PRODUCER_PersonType  PersonType = "Producer"
+	COMPOSER_PersonType  PersonType = "Composer"
+	GUESTSTAR_PersonType PersonType = "GuestStar"
 )
 
 const (
@@ -59,6 +69,18 @@ const (
 
 // A
 
+type ActivityLogEntry struct {
+	Id            int64     `json:"Id,omitempty"`
+	Name          string    `json:"Name,omitempty"`
+	Overview      string    `json:"Overview,omitempty"`
+	ShortOverview string    `json:"ShortOverview,omitempty"`
+	Type_         string    `json:"Type,omitempty"`
+	ItemId        string    `json:"ItemId,omitempty"`
+	Date          time.Time `json:"Date,omitempty"`
+	UserId        string    `json:"UserId,omitempty"`
+	Severity      string    `json:"Severity,omitempty"`
+}
+
 type AccessSchedule struct {
 	DayOfWeek *DynamicDayOfWeek `json:"DayOfWeek,omitempty"`
 	StartHour float64           `json:"StartHour,omitempty"`
@@ -83,7 +105,8 @@ type BaseItemDto struct {
 	Etag                         string                   `json:"Etag,omitempty"`
 	Prefix                       string                   `json:"Prefix,omitempty"`
 	PlaylistItemId               string                   `json:"PlaylistItemId,omitempty"`
-	DateCreated                  time.Time                `json:"DateCreated,omitempty"`
+	DateCreated                  *time.Time               `json:"DateCreated,omitempty"`
+	DateLastSaved                *time.Time               `json:"DateLastSaved,omitempty"`
 	ExtraType                    string                   `json:"ExtraType,omitempty"`
 	SortIndexNumber              int32                    `json:"SortIndexNumber,omitempty"`
 	SortParentIndexNumber        int32                    `json:"SortParentIndexNumber,omitempty"`
@@ -103,7 +126,7 @@ type BaseItemDto struct {
 	SortName                     string                   `json:"SortName,omitempty"`
 	ForcedSortName               string                   `json:"ForcedSortName,omitempty"`
 	Video3DFormat                *Video3DFormat           `json:"Video3DFormat,omitempty"`
-	PremiereDate                 time.Time                `json:"PremiereDate,omitempty"`
+	PremiereDate                 *time.Time               `json:"PremiereDate,omitempty"`
 	ExternalUrls                 []ExternalUrl            `json:"ExternalUrls,omitempty"`
 	MediaSources                 []MediaSourceInfo        `json:"MediaSources,omitempty"`
 	CriticRating                 float32                  `json:"CriticRating,omitempty"`
@@ -180,7 +203,7 @@ type BaseItemDto struct {
 	Chapters                     []ChapterInfo            `json:"Chapters,omitempty"`
 	LocationType                 *LocationType            `json:"LocationType,omitempty"`
 	MediaType                    string                   `json:"MediaType,omitempty"`
-	EndDate                      time.Time                `json:"EndDate,omitempty"`
+	EndDate                      *time.Time               `json:"EndDate,omitempty"`
 	LockedFields                 []MetadataFields         `json:"LockedFields,omitempty"`
 	LockData                     bool                     `json:"LockData,omitempty"`
 	Width                        int32                    `json:"Width,omitempty"`
@@ -199,7 +222,7 @@ type BaseItemDto struct {
 	IsoSpeedRating               int32                    `json:"IsoSpeedRating,omitempty"`
 	SeriesTimerId                string                   `json:"SeriesTimerId,omitempty"`
 	ChannelPrimaryImageTag       string                   `json:"ChannelPrimaryImageTag,omitempty"`
-	StartDate                    time.Time                `json:"StartDate,omitempty"`
+	StartDate                    *time.Time               `json:"StartDate,omitempty"`
 	CompletionPercentage         float64                  `json:"CompletionPercentage,omitempty"`
 	IsRepeat                     bool                     `json:"IsRepeat,omitempty"`
 	IsNew                        bool                     `json:"IsNew,omitempty"`
@@ -383,6 +406,11 @@ type NameLongIdPair struct {
 
 // P
 
+type ParentalRating struct {
+	Name  string `json:"Name,omitempty"`
+	Value int32  `json:"Value,omitempty"`
+}
+
 type PlayerStateInfo struct {
 	PositionTicks       int64       `json:"PositionTicks,omitempty"`
 	CanSeek             bool        `json:"CanSeek,omitempty"`
@@ -416,11 +444,21 @@ type ProcessStatistics struct {
 
 // Q
 
+type QueryResultActivityLogEntry struct {
+	Items            []ActivityLogEntry `json:"Items,omitempty"`
+	TotalRecordCount int32              `json:"TotalRecordCount,omitempty"`
+}
+
 type QueryResultBaseItemDto struct {
 	Items            []BaseItemDto `json:"Items,omitempty"`
 	TotalRecordCount int32         `json:"TotalRecordCount,omitempty"`
 }
 
+type QueryResultTimerInfoDto struct {
+	Items            []TimerInfoDto `json:"Items,omitempty"`
+	TotalRecordCount int32          `json:"TotalRecordCount,omitempty"`
+}
+
 // S
 
 type SessionInfo struct {
@@ -457,8 +495,87 @@ type SessionUserInfo struct {
 	UserInternalId int64  `json:"UserInternalId,omitempty"`
 }
 
+type SystemInfo struct {
+	ServerName                 string   `json:"ServerName,omitempty"`
+	Version                    string   `json:"Version,omitempty"`
+	Id                         string   `json:"Id,omitempty"`
+	OperatingSystem            string   `json:"OperatingSystem,omitempty"`
+	OperatingSystemDisplayName string   `json:"OperatingSystemDisplayName,omitempty"`
+	LocalAddress               string   `json:"LocalAddress,omitempty"`
+	LocalAddresses             []string `json:"LocalAddresses,omitempty"`
+	WanAddress                 string   `json:"WanAddress,omitempty"`
+	HttpServerPortNumber       int32    `json:"HttpServerPortNumber,omitempty"`
+	HttpsPortNumber            int32    `json:"HttpsPortNumber,omitempty"`
+	HasPendingRestart          bool     `json:"HasPendingRestart,omitempty"`
+	HasUpdateAvailable         bool     `json:"HasUpdateAvailable,omitempty"`
+	ProgramDataPath            string   `json:"ProgramDataPath,omitempty"`
+	CachePath                  string   `json:"CachePath,omitempty"`
+	LogPath                    string   `json:"LogPath,omitempty"`
+}
+
 // T
 
+type TaskInfo struct {
+	Name                      string            `json:"Name,omitempty"`
+	State                     string            `json:"State,omitempty"`
+	CurrentProgressPercentage float64           `json:"CurrentProgressPercentage,omitempty"`
+	Id                        string            `json:"Id,omitempty"`
+	LastExecutionResult       *TaskResult       `json:"LastExecutionResult,omitempty"`
+	Description               string            `json:"Description,omitempty"`
+	Category                  string            `json:"Category,omitempty"`
+	IsHidden                  bool              `json:"IsHidden,omitempty"`
+	Key                       string            `json:"Key,omitempty"`
+	Triggers                  []TaskTriggerInfo `json:"Triggers,omitempty"`
+}
+
+type TaskResult struct {
+	StartTimeUtc     time.Time `json:"StartTimeUtc,omitempty"`
+	EndTimeUtc       time.Time `json:"EndTimeUtc,omitempty"`
+	Status           string    `json:"Status,omitempty"`
+	Name             string    `json:"Name,omitempty"`
+	Key              string    `json:"Key,omitempty"`
+	Id               string    `json:"Id,omitempty"`
+	ErrorMessage     string    `json:"ErrorMessage,omitempty"`
+	LongErrorMessage string    `json:"LongErrorMessage,omitempty"`
+}
+
+type TaskTriggerInfo struct {
+	Type_           string `json:"Type,omitempty"`
+	TimeOfDayTicks  int64  `json:"TimeOfDayTicks,omitempty"`
+	IntervalTicks   int64  `json:"IntervalTicks,omitempty"`
+	DayOfWeek       string `json:"DayOfWeek,omitempty"`
+	MaxRuntimeTicks int64  `json:"MaxRuntimeTicks,omitempty"`
+}
+
+type TimerInfoDto struct {
+	Id                     string           `json:"Id,omitempty"`
+	Type_                  string           `json:"Type,omitempty"`
+	ServerId               string           `json:"ServerId,omitempty"`
+	ExternalId             string           `json:"ExternalId,omitempty"`
+	ChannelId              string           `json:"ChannelId,omitempty"`
+	ExternalChannelId      string           `json:"ExternalChannelId,omitempty"`
+	ChannelName            string           `json:"ChannelName,omitempty"`
+	ChannelPrimaryImageTag string           `json:"ChannelPrimaryImageTag,omitempty"`
+	ProgramId              string           `json:"ProgramId,omitempty"`
+	ExternalProgramId      string           `json:"ExternalProgramId,omitempty"`
+	Name                   string           `json:"Name,omitempty"`
+	Overview               string           `json:"Overview,omitempty"`
+	StartDate              time.Time        `json:"StartDate,omitempty"`
+	EndDate                time.Time        `json:"EndDate,omitempty"`
+	ServiceName            string           `json:"ServiceName,omitempty"`
+	Priority               int32            `json:"Priority,omitempty"`
+	PrePaddingSeconds      int32            `json:"PrePaddingSeconds,omitempty"`
+	PostPaddingSeconds     int32            `json:"PostPaddingSeconds,omitempty"`
+	IsPrePaddingRequired   bool             `json:"IsPrePaddingRequired,omitempty"`
+	IsPostPaddingRequired  bool             `json:"IsPostPaddingRequired,omitempty"`
+	Status                 *RecordingStatus `json:"Status,omitempty"`
+	SeriesTimerId          string           `json:"SeriesTimerId,omitempty"`
+	ExternalSeriesTimerId  string           `json:"ExternalSeriesTimerId,omitempty"`
+	RunTimeTicks           int64            `json:"RunTimeTicks,omitempty"`
+	ProgramInfo            *BaseItemDto     `json:"ProgramInfo,omitempty"`
+	TimerType              *LiveTvTimerType `json:"TimerType,omitempty"`
+}
+
 type TranscodingInfo struct {
 	AudioCodec                    string                    `json:"AudioCodec,omitempty"`
 	VideoCodec                    string                    `json:"VideoCodec,omitempty"`
@@ -615,3 +732,16 @@ type UserPolicy struct {
 	AllowCameraUpload                bool             `json:"AllowCameraUpload,omitempty"`
 	AllowSharingPersonalItems        bool             `json:"AllowSharingPersonalItems,omitempty"`
 }
+
+// V
+
+type VirtualFolderInfo struct {
+	Name            string   `json:"Name,omitempty"`
+	Locations       []string `json:"Locations,omitempty"`
+	CollectionType  string   `json:"CollectionType,omitempty"`
+	ItemId          string   `json:"ItemId,omitempty"`
+	Id              string   `json:"Id,omitempty"`
+	Guid            string   `json:"Guid,omitempty"`
+	RefreshProgress float64  `json:"RefreshProgress,omitempty"`
+	RefreshStatus   string   `json:"RefreshStatus,omitempty"`
+}
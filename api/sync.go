@@ -0,0 +1,52 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Incremental sync: items changed since the last sync are merged into the items of a snapshot
+// Deleted items are only detected by a full fetch, done at least every ReconcileInterval
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	ReconcileInterval = 24 * time.Hour
+	SyncMargin        = 5 * time.Minute // the clocks of client & server may differ
+)
+
+// MergeItems replaces the items changed, items not known yet are appended
+func MergeItems(items []BaseItemDto, changed []BaseItemDto) []BaseItemDto {
+	index := make(map[string]int, len(items))
+	result := make([]BaseItemDto, len(items), len(items)+len(changed))
+	copy(result, items)
+	for i, d := range result {
+		index[d.Id] = i
+	}
+	for _, d := range changed {
+		if i, ok := index[d.Id]; ok {
+			result[i] = d
+		} else {
+			index[d.Id] = len(result)
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// ChangedItems returns the ids of the items added or changed, compared to the items fetched before
+func ChangedItems(before []BaseItemDto, after []BaseItemDto) map[string]bool {
+	previous := make(map[string]BaseItemDto, len(before))
+	for _, d := range before {
+		previous[d.Id] = d
+	}
+	result := make(map[string]bool)
+	for _, d := range after {
+		p, ok := previous[d.Id]
+		if !ok || RefreshCompleted(p, d) || !reflect.DeepEqual(p.UserData, d.UserData) {
+			result[d.Id] = true
+		}
+	}
+	return result
+}
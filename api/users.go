@@ -0,0 +1,106 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// User administration overview: users with their access rights, for access audits
+// ---------------------------------------------------------------------------------------------------------------------
+
+package api
+
+import (
+	"Emby_Explorer/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const allLibraries = "All libraries"
+
+// LibraryNames maps the ids used in user policies (the library's Guid or item id) to the library's name
+func LibraryNames(folders []VirtualFolderInfo) map[string]string {
+	names := make(map[string]string)
+	for _, f := range folders {
+		if f.Guid != "" {
+			names[f.Guid] = f.Name
+		}
+		if f.ItemId != "" {
+			names[f.ItemId] = f.Name
+		}
+	}
+	return names
+}
+
+// GetUserDisplayData lists the users by name, Key is the user's id
+func GetUserDisplayData(users []UserDto, libraries map[string]string, ratings []ParentalRating) []models.ListData {
+	result := make([]models.ListData, 0, len(users))
+	sort.SliceStable(users, func(i, j int) bool {
+		return strings.ToLower(users[i].Name) < strings.ToLower(users[j].Name)
+	})
+	for _, u := range users {
+		var admin, disabled, hidden, folders, rating, blocked, remote, bitrate, liveTv, deletion, lastLogin, lastActivity string
+		if p := u.Policy; p != nil {
+			admin = evalFlag(p.IsAdministrator)
+			disabled = evalFlag(p.IsDisabled)
+			hidden = evalFlag(p.IsHidden)
+			folders = evalFolders(p.EnableAllFolders, p.EnabledFolders, libraries)
+			rating = evalParentalRating(p.MaxParentalRating, ratings)
+			blocked = strings.Join(p.BlockedTags, ", ")
+			remote = evalFlag(p.EnableRemoteAccess)
+			if p.RemoteClientBitrateLimit > 0 {
+				bitrate = evalBitrate(p.RemoteClientBitrateLimit)
+			}
+			liveTv = evalFlag(p.EnableLiveTvAccess)
+			deletion = evalFlag(p.EnableContentDeletion)
+		}
+		if !u.LastLoginDate.IsZero() {
+			lastLogin = u.LastLoginDate.Local().Format(DateFormat)
+		}
+		if !u.LastActivityDate.IsZero() {
+			lastActivity = u.LastActivityDate.Local().Format(DateFormat)
+		}
+		result = append(result, models.ListData{
+			Key: u.Id,
+			Fields: []string{u.Name, admin, disabled, hidden, lastLogin, lastActivity, folders, rating, blocked,
+				remote, bitrate, liveTv, deletion},
+		})
+	}
+	return result
+}
+
+func evalFlag(flag bool) string {
+	if flag {
+		return models.FlagYes
+	}
+	return ""
+}
+
+func evalFolders(all bool, enabled []string, libraries map[string]string) string {
+	if all {
+		return allLibraries
+	}
+	names := make([]string, 0, len(enabled))
+	for _, id := range enabled {
+		if name, ok := libraries[id]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, id)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// The ratings sharing the value allowed, e.g. "PG-13, TV-14"; no value means no restriction
+func evalParentalRating(value int32, ratings []ParentalRating) string {
+	if value == 0 {
+		return ""
+	}
+	names := make([]string, 0)
+	for _, r := range ratings {
+		if r.Value == value {
+			names = appendDistinct(names, r.Name)
+		}
+	}
+	if len(names) == 0 {
+		return strconv.Itoa(int(value))
+	}
+	return strings.Join(names, ", ")
+}
@@ -23,25 +23,180 @@ const (
 	CapSecure       = "Use https protocol"
 	CapDetails      = "Details"
 	CapExport       = "Export"
+	CapGrid         = "Grid"
+	CapShow         = "Show"
+	CapView         = "View"
+	CapUserData     = "Watch state columns"
+	CapLimits       = "Names per column..."
+	CapActors       = "Actors"
+	CapDirectors    = "Directors"
+	CapWriters      = "Writers"
+	CapGuestStars   = "Guest stars"
+	CapStudios      = "Studios"
+	CapProducers    = "Producers"
+	CapComposers    = "Composers"
 )
 
 const (
-	ErrAuthFailed       = "Authentication failed."
-	ErrFetchViewsFailed = "Error fetching Emby views for user."
-	ErrFetchItemsFailed = "Error fetching selected items for user."
+	CapFilterAll        = "All items"
+	CapFilterUnplayed   = "Unwatched"
+	CapFilterInProgress = "In progress"
+	CapFilterFavorites  = "Favourites"
+	CapMissing          = "Missing"
+	CapFilterNone       = "Nothing"
+	CapAudio            = "Audio"
+	CapSubtitles        = "Subtitles"
+	CapQuality          = "Quality"
+	CapFilterAnyHDR     = "HDR (any)"
+	CapFacets           = "Facet sidebar"
+	CapMatchAll         = "Match all checked"
+	CapMatchAny         = "Match any checked"
+	CapClear            = "Clear"
+)
+
+const (
+	CapItems           = "Items"
+	CapMarkPlayed      = "Mark as played"
+	CapMarkUnplayed    = "Mark as unplayed"
+	CapAddFavorite     = "Add to favourites"
+	CapRemoveFavorite  = "Remove from favourites"
+	CapEditMetadata    = "Edit metadata..."
+	CapPendingEdits    = "Pending edits"
+	CapCommit          = "Commit"
+	CapDiscard         = "Discard"
+	CapDiscardAll      = "Discard all"
+	CapSaving          = "Saving..."
+	CapLocked          = "(locked)"
+	CapBulkEdit        = "Bulk edit"
+	CapBulkExport      = "Export for bulk edit..."
+	CapBulkImport      = "Import bulk edit..."
+	CapInvalid         = "Invalid"
+	CapNotFound        = "Not found"
+	CapRefreshMetadata = "Refresh metadata..."
+	CapMetadataRefresh = "Metadata refresh"
+	CapRefreshOption   = "Refresh"
+	CapRefreshMissing  = "Fill in missing metadata only"
+	CapRefreshImages   = "Replace existing images"
+	CapRefreshAll      = "Replace all metadata"
+	CapRefreshing      = "Refreshing..."
+	CapQueued          = "Queued"
+	CapDone            = "Done"
+	CapNoChange        = "No change detected"
+)
+
+const (
+	CapFieldTitle         = "Title"
+	CapFieldOriginalTitle = "Original title"
+	CapFieldSortTitle     = "Sort title"
+	CapFieldTags          = "Tags"
+	CapFieldYear          = "Year"
+	CapFieldGenres        = "Genres"
+	CapFieldRating        = "Rating"
+	CapFieldOverview      = "Overview"
+)
+
+const (
+	CapServerMenu    = "Server"
+	CapSessions      = "Sessions"
+	CapRefresh       = "Refresh"
+	CapUpdated       = "Updated"
+	CapSnapshotOf    = "Snapshot of"
+	CapOffline       = "offline"
+	CapFetchAll      = "Fetch all items"
+	CapServerInfo    = "Server info"
+	CapScanAll       = "Scan all libraries"
+	CapScanLibrary   = "Scan library"
+	CapTasks         = "Scheduled tasks"
+	CapTasksRunning  = "Tasks running"
+	CapActivityLog   = "Activity log"
+	CapLast24Hours   = "Last 24 hours"
+	CapLast7Days     = "Last 7 days"
+	CapLast30Days    = "Last 30 days"
+	CapWholeLog      = "Whole log"
+	CapAllTypes      = "All types"
+	CapEntries       = "Entries"
+	CapUsers         = "Users"
+	CapLibraries     = "Libraries"
+	CapLibraryAccess = "Library access"
+	CapItemAccess    = "Who can see this?"
+)
+
+const (
+	CapReports      = "Reports"
+	CapStatistics   = "Statistics"
+	CapTable        = "Table"
+	CapOther        = "Other"
+	CapDuplicates   = "Duplicates"
+	CapSearching    = "Searching..."
+	CapGroups       = "Groups"
+	CapEpisodes     = "Episode check"
+	CapIssues       = "Issues"
+	CapHealth       = "Health check"
+	CapRules        = "Rules"
+	CapSeverity     = "Severity"
+	CapAllRules     = "All rules"
+	CapPeople       = "People"
+	CapFilmography  = "Filmography"
+	CapSearchServer = "Search server"
+	CapSnapshotDiff = "Snapshot diff"
+	CapFrom         = "From"
+	CapTo           = "To"
+	CapLive         = "Live (server)"
+	CapCompare      = "Compare"
+	CapAdded        = "Added"
+	CapRemoved      = "Removed"
+	CapChanged      = "Changed"
+)
+
+const (
+	ErrAuthFailed        = "Authentication failed."
+	ErrFetchViewsFailed  = "Error fetching Emby views for user."
+	ErrFetchItemsFailed  = "Error fetching selected items for user."
+	ErrFetchSessions     = "Error fetching sessions."
+	ErrUserDataFailed    = "Error updating watch state, changes have been reverted."
+	ErrInvalidValue      = "Invalid value, nothing has been staged."
+	ErrCommitFailed      = "Some items could not be updated, their edits are still pending."
+	ErrImportFailed      = "Error reading the bulk edit sheet."
+	ErrImportIncomplete  = "Some changes of the sheet have not been staged."
+	ErrFetchServer       = "Error fetching server information (administrator required)."
+	ErrScanFailed        = "Error starting the library scan."
+	ErrFetchActivity     = "Error fetching the activity log (administrator required)."
+	ErrFetchUsers        = "Error fetching users (administrator required)."
+	ErrNoSnapshots       = "No snapshots of this view have been taken."
+	ErrOneSnapshot       = "Only one snapshot of this view has been taken, there is nothing to compare."
+	ErrNoEarlierSnapshot = "There is no snapshot taken before the newest one in the period given."
+	ErrUnknownView       = "Unknown view, no snapshots have been taken:"
 )
 
 const (
 	CapMovies     = "Movies"
 	CapTVShows    = "TV Shows"
 	CapHomeVideos = "Home Videos"
+	CapPhotos     = "Photos"
+	CapLiveTv     = "Live TV"
+	CapChannels   = "Channels"
+	CapGuide      = "Guide"
+	CapTimers     = "Timers"
+	CapRecordings = "Recordings"
 	CapEmby       = "Emby"
 )
 
-const FileExtension = "xlsx"
+const (
+	FileExtension    = "xlsx"
+	GpxFileExtension = "gpx"
+	KmlFileExtension = "kml"
+)
 
 const (
 	TxtAboutEmbyExplorer = "Emby Explorer (w) 2024 by Jan Buchholz\nhttps://github.com/SideFx/EmbyExplorer"
 	TxtAboutUnison       = "\n\nCredits:\nEmby Explorer uses the following libraries:\nUnison by Richard A. Wilkes\nhttps://github.com/richardwilkes/unison"
 	TxtAboutExcelize     = "\nExcelize\nhttps://xuri.me/excelize/\nhttps://github.com/qax-os/excelize"
+	TxtItemLocked        = "The metadata of this item is locked."
+)
+
+// Command line options
+const (
+	OptDiff   = "compare the snapshots of the view named and export the differences, without opening a window"
+	OptSince  = "compare the newest snapshot to the one taken this long before"
+	OptOutput = "file the differences are exported to"
 )
@@ -24,3 +24,6 @@ var EmbyLogo string
 
 //go:embed export.svg
 var IconExport string
+
+//go:embed grid.svg
+var IconGrid string
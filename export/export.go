@@ -8,6 +8,7 @@ package export
 
 import (
 	"github.com/xuri/excelize/v2"
+	"time"
 )
 
 type Payload struct {
@@ -20,9 +21,38 @@ type HeaderData struct {
 	Name    string
 	Column  string
 	Width   float64
+	Hidden  bool // e.g. ids needed to read the sheet back
+}
+
+const creator = "Emby Explorer"
+
+// Document properties of every file exported, the server is known once connected (see SetServerInfo)
+var docProps = excelize.DocProperties{Creator: creator}
+
+// SetServerInfo records the server the data is exported from
+func SetServerInfo(name string, version string) {
+	docProps.Subject = name
+	docProps.Description = "Emby Server " + version
+}
+
+// ColumnName returns the XLS column name for a 1-based column number, e.g. 28 -> AB
+func ColumnName(col int) string {
+	name, _ := excelize.ColumnNumberToName(col)
+	return name
+}
+
+// Sheet is a sheet of a file exported, the first sheet of a file is the active one
+type Sheet struct {
+	Name   string
+	Header []HeaderData
+	Data   []Payload
 }
 
 func XlsxExport(data []Payload, header []HeaderData, path string, sheet string) error {
+	return XlsxExportSheets([]Sheet{{Name: sheet, Header: header, Data: data}}, path)
+}
+
+func XlsxExportSheets(sheets []Sheet, path string) error {
 	var err error
 	f := excelize.NewFile()
 	var headerFont = excelize.Font{
@@ -59,37 +89,63 @@ func XlsxExport(data []Payload, header []HeaderData, path string, sheet string)
 			err = e
 		}
 	}()
-	index, err := f.NewSheet(sheet)
+	for i, sheet := range sheets {
+		index, err := writeSheet(f, sheet, headerStyleId, standardStyleId)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			f.SetActiveSheet(index)
+		}
+	}
+	props := docProps
+	props.Title = sheets[0].Name
+	props.Created = time.Now().Format(time.RFC3339)
+	err = f.SetDocProps(&props)
 	if err != nil {
 		return err
 	}
+	err = f.SaveAs(path)
+	return err
+}
+
+func writeSheet(f *excelize.File, s Sheet, headerStyleId int, standardStyleId int) (int, error) {
+	sheet := s.Name
+	index, err := f.NewSheet(sheet)
+	if err != nil {
+		return index, err
+	}
 	// Set header
-	for _, h := range header {
+	for _, h := range s.Header {
 		err = f.SetCellStr(sheet, h.XLSCell, h.Name)
 		if err != nil {
-			return err
+			return index, err
 		}
 		err = f.SetCellStyle(sheet, h.XLSCell, h.XLSCell, headerStyleId)
 		if err != nil {
-			return err
+			return index, err
 		}
 		err = f.SetColWidth(sheet, h.Column, h.Column, h.Width)
 		if err != nil {
-			return err
+			return index, err
+		}
+		if h.Hidden {
+			err = f.SetColVisible(sheet, h.Column, false)
+			if err != nil {
+				return index, err
+			}
 		}
 	}
 	// Set data
-	for _, d := range data {
+	for _, d := range s.Data {
 		err = f.SetCellStr(sheet, d.XLSCell, d.Data)
 		if err != nil {
-			return err
+			return index, err
 		}
 		err = f.SetCellStyle(sheet, d.XLSCell, d.XLSCell, standardStyleId)
 		if err != nil {
-			return err
+			return index, err
 		}
 	}
-	f.SetActiveSheet(index)
-	err = f.SaveAs(path)
-	return err
+	return index, nil
 }
@@ -0,0 +1,104 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// GPX & KML export of geotagged items
+// ---------------------------------------------------------------------------------------------------------------------
+
+package export
+
+import (
+	"encoding/xml"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	gpxVersion   = "1.1"
+	gpxNamespace = "http://www.topografix.com/GPX/1/1"
+	kmlNamespace = "http://www.opengis.net/kml/2.2"
+)
+
+type Waypoint struct {
+	Name        string
+	Description string
+	Latitude    float64
+	Longitude   float64
+	Altitude    float64
+	Time        time.Time
+}
+
+type gpx struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+type gpxWaypoint struct {
+	Latitude    float64 `xml:"lat,attr"`
+	Longitude   float64 `xml:"lon,attr"`
+	Elevation   string  `xml:"ele,omitempty"`
+	Time        string  `xml:"time,omitempty"`
+	Name        string  `xml:"name"`
+	Description string  `xml:"desc,omitempty"`
+}
+
+type kml struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string   `xml:"name"`
+	Description string   `xml:"description,omitempty"`
+	Point       kmlPoint `xml:"Point"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+func GpxExport(points []Waypoint, path string, creator string) error {
+	doc := gpx{Version: gpxVersion, Creator: creator, Xmlns: gpxNamespace}
+	for _, p := range points {
+		w := gpxWaypoint{Latitude: p.Latitude, Longitude: p.Longitude, Name: p.Name, Description: p.Description}
+		if p.Altitude != 0 {
+			w.Elevation = strconv.FormatFloat(p.Altitude, 'f', -1, 64)
+		}
+		if !p.Time.IsZero() {
+			w.Time = p.Time.UTC().Format(time.RFC3339)
+		}
+		doc.Waypoints = append(doc.Waypoints, w)
+	}
+	return writeXml(doc, path)
+}
+
+func KmlExport(points []Waypoint, path string, document string) error {
+	doc := kml{Xmlns: kmlNamespace, Document: kmlDocument{Name: document}}
+	for _, p := range points {
+		// KML expects longitude first
+		c := strconv.FormatFloat(p.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(p.Latitude, 'f', -1, 64)
+		if p.Altitude != 0 {
+			c = c + "," + strconv.FormatFloat(p.Altitude, 'f', -1, 64)
+		}
+		doc.Document.Placemarks = append(doc.Document.Placemarks,
+			kmlPlacemark{Name: p.Name, Description: p.Description, Point: kmlPoint{Coordinates: c}})
+	}
+	return writeXml(doc, path)
+}
+
+func writeXml(doc any, path string) error {
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append([]byte(xml.Header), b...)
+	return os.WriteFile(path, b, 0644)
+}
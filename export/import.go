@@ -0,0 +1,57 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// XLSX import of sheets written by XlsxExport, using Excelite by xuri
+// https://github.com/qax-os/excelize
+// ---------------------------------------------------------------------------------------------------------------------
+
+package export
+
+import (
+	"errors"
+	"github.com/xuri/excelize/v2"
+	"strings"
+)
+
+// XlsxImport reads the active sheet, the first row holds the column names
+// Every further row is returned as a map column name -> cell value, empty rows are skipped
+func XlsxImport(path string, required ...string) ([]map[string]string, error) {
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	rows, err := f.GetRows(f.GetSheetName(f.GetActiveSheetIndex()))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("sheet is empty")
+	}
+	header := rows[0]
+	for _, r := range required {
+		found := false
+		for _, h := range header {
+			found = found || strings.TrimSpace(h) == r
+		}
+		if !found {
+			return nil, errors.New("column missing: " + r)
+		}
+	}
+	result := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		values := make(map[string]string)
+		empty := true
+		for i, cell := range row {
+			if i < len(header) {
+				values[strings.TrimSpace(header[i])] = cell
+				empty = empty && strings.TrimSpace(cell) == ""
+			}
+		}
+		if !empty {
+			result = append(result, values)
+		}
+	}
+	return result, nil
+}
@@ -2,7 +2,11 @@ module Emby_Explorer
 
 go 1.23.2
 
-require github.com/richardwilkes/unison v0.74.0
+require (
+	github.com/richardwilkes/toolbox v1.120.0
+	github.com/richardwilkes/unison v0.74.0
+	github.com/xuri/excelize/v2 v2.8.1
+)
 
 require (
 	github.com/go-gl/gl v0.0.0-20231021071112-07e5d0ea2e71 // indirect
@@ -15,9 +19,7 @@ require (
 	github.com/richardlehane/mscfb v1.0.4 // indirect
 	github.com/richardlehane/msoleps v1.0.3 // indirect
 	github.com/richardwilkes/json v0.3.0 // indirect
-	github.com/richardwilkes/toolbox v1.120.0 // indirect
 	github.com/xuri/efp v0.0.0-20231025114914-d1ff6096ae53 // indirect
-	github.com/xuri/excelize/v2 v2.8.1 // indirect
 	github.com/xuri/nfp v0.0.0-20230919160717-d98342af3f05 // indirect
 	github.com/yuin/goldmark v1.7.4 // indirect
 	github.com/zeebo/xxh3 v1.0.2 // indirect
@@ -2,16 +2,49 @@
 // (w) 2024 by Jan Buchholz
 // Main function (program startup), using Unison library (c) Richard A. Wilkes
 // https://github.com/richardwilkes/unison
+// With -diff, the snapshot diff of a view is exported without opening a window, e.g.
+// Emby_Explorer -diff Movies -since 168h -o new.xlsx
 // ---------------------------------------------------------------------------------------------------------------------
 
 package main
 
 import (
+	"Emby_Explorer/assets"
 	"Emby_Explorer/ui"
+	"flag"
+	"fmt"
 	"github.com/richardwilkes/unison"
+	"io"
+	"os"
+	"strings"
+	"time"
 )
 
 func main() {
+	// arguments not known are left to the GUI (e.g. passed by a launcher), only -diff runs without a window
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	diffView := flags.String("diff", "", assets.OptDiff)
+	diffSince := flags.Duration("since", 7*24*time.Hour, assets.OptSince)
+	diffOutput := flags.String("o", assets.CapSnapshotDiff+"."+assets.FileExtension, assets.OptOutput)
+	// -diff is looked for first, an unknown flag before it stops parsing
+	if diffRequested(os.Args[1:]) {
+		if err := flags.Parse(os.Args[1:]); err != nil || *diffView == "" {
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			flags.SetOutput(os.Stderr)
+			flags.PrintDefaults()
+			os.Exit(2)
+		}
+		summary, err := ui.ExportSnapshotDiff(*diffView, *diffSince, *diffOutput)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(summary)
+		return
+	}
 	unison.Start(
 		unison.StartupFinishedCallback(func() {
 			err := ui.NewMainWindow()
@@ -24,3 +57,15 @@ func main() {
 		}),
 	)
 }
+
+func diffRequested(args []string) bool {
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		if a == "-diff" || a == "--diff" || strings.HasPrefix(a, "-diff=") || strings.HasPrefix(a, "--diff=") {
+			return true
+		}
+	}
+	return false
+}
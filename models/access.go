@@ -0,0 +1,27 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Library access of users, displayed as lists (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+// Columns of the access matrix, a column per library is inserted after the user
+var AccessMatrixColumns = []ColumnDescription{
+	{"User", "A", 25},
+	{"Max. rating", "", 15},
+	{"Blocked tags", "", 30},
+}
+
+const AccessLibraryColumnWidth = 15
+
+var ItemAccessTableDescription = TableDescription{
+	NoOfColumns: 5,  //displayed columns only
+	APIFields:   "", //users are not queried by fields
+	Columns: []ColumnDescription{
+		{"User", "A", 25},
+		{"Access", "B", 10},
+		{"Item rating", "C", 15},
+		{"Max. rating", "D", 15},
+		{"Reason", "E", 60},
+	},
+}
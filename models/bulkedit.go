@@ -0,0 +1,25 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Sheet layout for bulk metadata edits (export, edit in Excel, import), the hidden Id column identifies the items
+// Columns are found by their caption when the sheet is read back, so captions must not change
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var BulkEditTableDescription = TableDescription{
+	NoOfColumns: 11, //all columns are exported
+	APIFields:   "", //uses the items of the current view
+	Columns: []ColumnDescription{
+		{"Id", "A", 20},
+		{"Type", "B", 10},
+		{"Series", "C", 40},
+		{"Title", "D", 60},
+		{"Sort title", "E", 60},
+		{"Original title", "F", 60},
+		{"Year", "G", 8},
+		{"Genres", "H", 50},
+		{"Tags", "I", 50},
+		{"Rating", "J", 10},
+		{"Path", "K", 80},
+	},
+}
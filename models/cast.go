@@ -0,0 +1,16 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Cast & crew of an item (details window), displayed as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var CastTableDescription = TableDescription{
+	NoOfColumns: 3,  //displayed columns only
+	APIFields:   "", //people are part of the item
+	Columns: []ColumnDescription{
+		{"Name", "A", 40},
+		{"Type", "B", 15},
+		{"Role", "C", 40},
+	},
+}
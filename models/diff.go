@@ -0,0 +1,21 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Snapshot diff, displayed as a list (see list.go)
+// Every item changed is a parent row, the fields changed are its children
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var DiffTableDescription = TableDescription{
+	NoOfColumns: 7,  //displayed columns only
+	APIFields:   "", //compares snapshots of the current view
+	Columns: []ColumnDescription{
+		{"Change", "A", 12},
+		{"Type", "B", 12},
+		{"Title", "C", 60},
+		{"Field", "D", 15},
+		{"Before", "E", 50},
+		{"After", "F", 50},
+		{"Path", "G", 100},
+	},
+}
@@ -0,0 +1,24 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Duplicate finder results, displayed as a list (see list.go)
+// Every group of duplicates is a parent row, the copies are its children
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var DuplicateTableDescription = TableDescription{
+	NoOfColumns: 10, //displayed columns only
+	APIFields:   "", //uses the items of all video views
+	Columns: []ColumnDescription{
+		{"Title", "A", 50},
+		{"Year", "B", 10},
+		{"View", "C", 20},
+		{"Time", "D", 10},
+		{"Resolution", "E", 15},
+		{"Quality", "F", 25},
+		{"Codec", "G", 20},
+		{"Size", "H", 12},
+		{"Match", "I", 30},
+		{"Path", "J", 100},
+	},
+}
@@ -0,0 +1,40 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata edits staged for review, displayed as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var EditTableDescription = TableDescription{
+	NoOfColumns: 5,  //displayed columns only
+	APIFields:   "", //edits are kept locally until committed
+	Columns: []ColumnDescription{
+		{"Title", "A", 50},
+		{"Field", "B", 20},
+		{"Current", "C", 50},
+		{"New", "D", 50},
+		{"Status", "E", 40},
+	},
+}
+
+// MetadataEdit is a new value for a field of an item, Status holds the error of a failed commit
+type MetadataEdit struct {
+	ItemId  string
+	Title   string
+	Field   string // as named by Emby
+	Caption string // as displayed
+	Old     string
+	New     string
+	Status  string
+}
+
+func (e MetadataEdit) Key() string {
+	return e.ItemId + ":" + e.Field
+}
+
+func (e MetadataEdit) ListData() ListData {
+	return ListData{
+		Key:    e.Key(),
+		Fields: []string{e.Title, e.Caption, e.Old, e.New, e.Status},
+	}
+}
@@ -0,0 +1,21 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Episode check results, displayed as a list (see list.go)
+// Every series with issues is a parent row, the issues are its children; "Done" is left to be ticked off
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var EpisodeReportTableDescription = TableDescription{
+	NoOfColumns: 7,  //displayed columns only
+	APIFields:   "", //uses the items of the current TV view, plus the missing episodes
+	Columns: []ColumnDescription{
+		{"Series", "A", 50},
+		{"Season", "B", 30},
+		{"Episode", "C", 15},
+		{"Title", "D", 50},
+		{"Issue", "E", 30},
+		{"Path", "F", 100},
+		{"Done", "G", 8},
+	},
+}
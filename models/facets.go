@@ -0,0 +1,60 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Facets of the current view (genres, studios, tags, years, ratings) with their counts, used as row filter
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+type FacetKind int
+
+const (
+	FacetGenre FacetKind = iota
+	FacetStudio
+	FacetTag
+	FacetYear
+	FacetRating
+)
+
+// Titles in the order of the kinds
+var FacetKinds = []string{"Genres", "Studios", "Tags", "Years", "Ratings"}
+
+type FacetValue struct {
+	Kind  FacetKind
+	Value string
+}
+
+type FacetCount struct {
+	FacetValue
+	Count int
+}
+
+type Facets struct {
+	Counts [][]FacetCount          // per kind, values sorted as displayed
+	Items  map[string][]FacetValue // facet values by item id
+}
+
+// FacetSelection matches items having all (MatchAll) or any of the selected values
+type FacetSelection struct {
+	Selected map[FacetValue]bool
+	MatchAll bool
+}
+
+func (s FacetSelection) Active() bool {
+	return len(s.Selected) > 0
+}
+
+func (s FacetSelection) Matches(values []FacetValue) bool {
+	if !s.Active() {
+		return true
+	}
+	found := 0
+	for _, v := range values {
+		if s.Selected[v] {
+			if !s.MatchAll {
+				return true
+			}
+			found++
+		}
+	}
+	return s.MatchAll && found == len(s.Selected)
+}
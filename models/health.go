@@ -0,0 +1,75 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Health check results, displayed as a list (see list.go)
+// The rules themselves are evaluated in api/health.go
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var HealthTableDescription = TableDescription{
+	NoOfColumns: 6,  //displayed columns only
+	APIFields:   "", //checks the items fetched
+	Columns: []ColumnDescription{
+		{"Severity", "A", 10},
+		{"Rule", "B", 30},
+		{"Title", "C", 50},
+		{"Type", "D", 10},
+		{"Year", "E", 10},
+		{"Path", "F", 100},
+	},
+}
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"Info", "Warning", "Error"}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+type HealthRule struct {
+	Id       string
+	Name     string
+	Severity Severity
+}
+
+type HealthIssue struct {
+	Rule   HealthRule
+	ItemId string
+	Title  string
+	Type_  string
+	Year   string
+	Path   string
+}
+
+// HealthFilter restricts the issues displayed, a nil severity or an empty rule id means "any"
+type HealthFilter struct {
+	Severity *Severity
+	RuleId   string
+}
+
+func (i HealthIssue) Matches(f HealthFilter) bool {
+	return (f.Severity == nil || i.Rule.Severity == *f.Severity) && (f.RuleId == "" || i.Rule.Id == f.RuleId)
+}
+
+// HealthListData returns the issues matching the filter, most severe first (the order of issues is kept otherwise)
+func HealthListData(issues []HealthIssue, f HealthFilter) []ListData {
+	result := make([]ListData, 0)
+	for s := SeverityError; s >= SeverityInfo; s-- {
+		for _, i := range issues {
+			if i.Rule.Severity == s && i.Matches(f) {
+				result = append(result, ListData{
+					Key:    i.ItemId + ":" + i.Rule.Id,
+					Fields: []string{s.String(), i.Rule.Name, i.Title, i.Type_, i.Year, i.Path},
+				})
+			}
+		}
+	}
+	return result
+}
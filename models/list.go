@@ -0,0 +1,116 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Generic data model for report & monitor windows, according to Unison's table model
+// Every row is a list of display strings in column order, rows may have children
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/fatal"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+)
+
+var _ unison.TableRowData[*ListRow] = &ListRow{}
+
+type ListData struct {
+	Fields   []string
+	Key      string // Emby id of the object the row refers to, if any
+	Children []ListData
+}
+
+type ListRow struct {
+	table        *unison.Table[*ListRow]
+	parent       *ListRow
+	children     []*ListRow
+	container    bool
+	open         bool
+	doubleHeight bool
+	id           tid.TID
+	M            ListData
+}
+
+func (d *ListRow) CloneForTarget(target unison.Paneler, newParent *ListRow) *ListRow {
+	table, ok := target.(*unison.Table[*ListRow])
+	if !ok {
+		fatal.IfErr(errs.New("invalid target"))
+	}
+	clone := *d
+	clone.table = table
+	clone.parent = newParent
+	clone.id = tid.MustNewTID('a')
+	return &clone
+}
+
+func (d *ListRow) ID() tid.TID {
+	return d.id
+}
+
+func (d *ListRow) Parent() *ListRow {
+	return d.parent
+}
+
+func (d *ListRow) SetParent(parent *ListRow) {
+	d.parent = parent
+}
+
+func (d *ListRow) CanHaveChildren() bool {
+	return d.container
+}
+
+func (d *ListRow) Children() []*ListRow {
+	return d.children
+}
+
+func (d *ListRow) SetChildren(children []*ListRow) {
+	d.children = children
+}
+
+func (d *ListRow) CellDataForSort(col int) string {
+	return GetListDataField(col, d.M)
+}
+
+func (d *ListRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool) unison.Paneler {
+	text := GetListDataField(col, d.M)
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
+	addText(wrapper, text, foreground, unison.LabelFont)
+	return wrapper
+}
+
+func (d *ListRow) IsOpen() bool {
+	return d.open
+}
+
+func (d *ListRow) SetOpen(open bool) {
+	d.open = open
+}
+
+func NewListRow(id tid.TID, table *unison.Table[*ListRow], data ListData) *ListRow {
+	row := &ListRow{
+		table:     table,
+		id:        id,
+		container: len(data.Children) > 0,
+		open:      len(data.Children) > 0,
+		parent:    nil,
+		children:  nil,
+		M:         data,
+	}
+	for _, c := range data.Children {
+		child := NewListRow(tid.MustNewTID('a'), table, c)
+		child.parent = row
+		row.children = append(row.children, child)
+	}
+	return row
+}
+
+func GetListDataField(index int, structure ListData) string {
+	if index >= 0 && index < len(structure.Fields) {
+		return structure.Fields[index]
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Data model for Emby Live TV (channels, guide, timers & recordings), according to Unison's table model
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/fatal"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+)
+
+var LiveTvDataTable []LiveTvData
+
+var _ unison.TableRowData[*LiveTvRow] = &LiveTvRow{}
+var LiveTvTable *unison.Table[*LiveTvRow]
+var LiveTvTableDescription = TableDescription{
+	NoOfColumns: 10, //displayed columns only
+	APIFields: "Name,ChannelInfo,ChannelNumber,ChannelName,EpisodeTitle,StartDate,EndDate,RunTimeTicks,Overview,Path," +
+		"IsMovie,IsSeries,IsSports,IsNews,IsKids,TimerId,SeriesTimerId", //no spaces here!
+	Columns: []ColumnDescription{
+		{"No.", "A", 8},
+		{"Channel", "B", 30},
+		{"Title", "C", 50},
+		{"Episode", "D", 50},
+		{"Start", "E", 20},
+		{"End", "F", 20},
+		{"Time", "G", 10},
+		{"Category", "H", 20},
+		{"Status", "I", 20},
+		{"Path", "J", 80},
+	},
+}
+
+type LiveTvData struct {
+	Number       string
+	Channel      string
+	Title        string
+	EpisodeTitle string
+	Start        string
+	End          string
+	Runtime      string
+	Category     string
+	Status       string
+	Path         string
+	Overview     string
+	ChannelId    string
+	ItemId       string
+	UserData
+}
+
+type LiveTvRow struct {
+	table        *unison.Table[*LiveTvRow]
+	parent       *LiveTvRow
+	children     []*LiveTvRow
+	container    bool
+	open         bool
+	doubleHeight bool
+	id           tid.TID
+	M            LiveTvData
+}
+
+func (d *LiveTvRow) CloneForTarget(target unison.Paneler, newParent *LiveTvRow) *LiveTvRow {
+	table, ok := target.(*unison.Table[*LiveTvRow])
+	if !ok {
+		fatal.IfErr(errs.New("invalid target"))
+	}
+	clone := *d
+	clone.table = table
+	clone.parent = newParent
+	clone.id = tid.MustNewTID('a')
+	return &clone
+}
+
+func (d *LiveTvRow) ID() tid.TID {
+	return d.id
+}
+
+func (d *LiveTvRow) Parent() *LiveTvRow {
+	return d.parent
+}
+
+func (d *LiveTvRow) SetParent(parent *LiveTvRow) {
+	d.parent = parent
+}
+
+func (d *LiveTvRow) CanHaveChildren() bool {
+	return d.container
+}
+
+func (d *LiveTvRow) Children() []*LiveTvRow {
+	return d.children
+}
+
+func (d *LiveTvRow) SetChildren(children []*LiveTvRow) {
+	d.children = children
+}
+
+func (d *LiveTvRow) CellDataForSort(col int) string {
+	return GetLiveTvDataField(col, d.M)
+}
+
+func (d *LiveTvRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool) unison.Paneler {
+	text := GetLiveTvDataField(col, d.M)
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
+	addText(wrapper, text, foreground, unison.LabelFont)
+	return wrapper
+}
+
+func (d *LiveTvRow) IsOpen() bool {
+	return d.open
+}
+
+func (d *LiveTvRow) SetOpen(open bool) {
+	d.open = open
+}
+
+func NewLiveTvRow(id tid.TID, data LiveTvData) *LiveTvRow {
+	row := &LiveTvRow{
+		table:     LiveTvTable,
+		id:        id,
+		container: false,
+		open:      false,
+		parent:    nil,
+		children:  nil,
+		M:         data,
+	}
+	return row
+}
+
+func GetLiveTvDataField(index int, structure LiveTvData) string {
+	switch index {
+	case 0:
+		return structure.Number
+	case 1:
+		return structure.Channel
+	case 2:
+		return structure.Title
+	case 3:
+		return structure.EpisodeTitle
+	case 4:
+		return structure.Start
+	case 5:
+		return structure.End
+	case 6:
+		return structure.Runtime
+	case 7:
+		return structure.Category
+	case 8:
+		return structure.Status
+	case 9:
+		return structure.Path
+	default:
+		return GetUserDataField(index-LiveTvTableDescription.NoOfColumns, structure.UserData)
+	}
+}
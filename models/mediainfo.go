@@ -0,0 +1,122 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Properties derived from all media streams of a video item, shared by the video data models
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Resolution classes & dynamic ranges, in ascending order
+var ResolutionClasses = []string{"SD", "720p", "1080p", "4K"}
+var DynamicRanges = []string{RangeSDR, RangeHLG, RangeHDR10, RangeHDR10Plus, RangeDolbyVision}
+
+const (
+	RangeSDR         = "SDR"
+	RangeHLG         = "HLG"
+	RangeHDR10       = "HDR10"
+	RangeHDR10Plus   = "HDR10+"
+	RangeDolbyVision = "Dolby Vision"
+)
+
+type MediaInfo struct {
+	AudioLanguages    []string // distinct, in stream order
+	SubtitleLanguages []string
+	ResolutionClass   string // of the main video stream, see ResolutionClasses
+	DynamicRange      string // see DynamicRanges
+	BitDepth          int32
+}
+
+func (m MediaInfo) Audio() string {
+	return strings.Join(m.AudioLanguages, ", ")
+}
+
+func (m MediaInfo) Subtitles() string {
+	return strings.Join(m.SubtitleLanguages, ", ")
+}
+
+// Quality e.g. "4K Dolby Vision 10 bit"
+func (m MediaInfo) Quality() string {
+	var parts []string
+	for _, p := range []string{m.ResolutionClass, m.DynamicRange} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if m.BitDepth > 0 {
+		parts = append(parts, strconv.Itoa(int(m.BitDepth))+" bit")
+	}
+	return strings.Join(parts, " ")
+}
+
+// QualityRank sorts by resolution class, then dynamic range, then bit depth
+func (m MediaInfo) QualityRank() string {
+	return strconv.Itoa(indexOf(ResolutionClasses, m.ResolutionClass)+1) +
+		strconv.Itoa(indexOf(DynamicRanges, m.DynamicRange)+1) + strconv.Itoa(100+int(m.BitDepth))
+}
+
+func (m MediaInfo) IsHDR() bool {
+	return m.DynamicRange != "" && m.DynamicRange != RangeSDR
+}
+
+// QualityFilter selects by resolution class and/or dynamic range, the zero value selects everything
+type QualityFilter struct {
+	ResolutionClass string
+	DynamicRange    string
+	AnyHDR          bool
+}
+
+func (f QualityFilter) Active() bool {
+	return f.ResolutionClass != "" || f.DynamicRange != "" || f.AnyHDR
+}
+
+func (m MediaInfo) MatchesQuality(filter QualityFilter) bool {
+	if filter.ResolutionClass != "" && m.ResolutionClass != filter.ResolutionClass {
+		return false
+	}
+	if filter.DynamicRange != "" && m.DynamicRange != filter.DynamicRange {
+		return false
+	}
+	if filter.AnyHDR && !m.IsHDR() {
+		return false
+	}
+	return true
+}
+
+func indexOf(list []string, value string) int {
+	for i, l := range list {
+		if l == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// LanguageFilter selects items lacking an audio or subtitle track in a language, the zero value selects everything
+type LanguageFilter struct {
+	Subtitles bool
+	Language  string
+}
+
+func (f LanguageFilter) Active() bool {
+	return f.Language != ""
+}
+
+func (m MediaInfo) Matches(filter LanguageFilter) bool {
+	if !filter.Active() {
+		return true
+	}
+	languages := m.AudioLanguages
+	if filter.Subtitles {
+		languages = m.SubtitleLanguages
+	}
+	for _, l := range languages {
+		if strings.EqualFold(l, filter.Language) {
+			return false
+		}
+	}
+	return true
+}
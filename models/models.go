@@ -30,6 +30,9 @@ var MovieDataTable []MovieData
 var TVShowDataTable []TVShowData
 var HomeVideoDataTable []HomeVideoData
 
+// Ids of the items added or changed by the last sync, their rows are highlighted
+var ChangedItems = make(map[string]bool)
+
 // ---------------------------------------------------------------------------------------------------------------------
 // Movies model
 // ---------------------------------------------------------------------------------------------------------------------
@@ -37,9 +40,10 @@ var HomeVideoDataTable []HomeVideoData
 var _ unison.TableRowData[*MovieRow] = &MovieRow{}
 var MovieTable *unison.Table[*MovieRow]
 var MovieTableDescription = TableDescription{
-	NoOfColumns: 12, //displayed columns only
+	NoOfColumns: 15, //displayed columns only
 	APIFields: "Name,OriginalTitle,MediaSources,Path,Genres,ProductionYear,People,Studios,Width,Height,Container," +
-		"Overview,RunTimeTicks,Type_", //no spaces here!
+		"Overview,RunTimeTicks,ProviderIds,LockData,LockedFields,SortName,ForcedSortName,TagItems,OfficialRating," +
+		"DateCreated,DateLastSaved,Etag,Type_", //no spaces here!
 	Columns: []ColumnDescription{
 		{"Title", "A", 70},
 		{"Original Title", "B", 70},
@@ -52,7 +56,10 @@ var MovieTableDescription = TableDescription{
 		{"Ext.", "I", 10},
 		{"Codec", "J", 20},
 		{"Resolution", "K", 15},
-		{"Path", "L", 80},
+		{"Quality", "L", 25},
+		{"Audio", "M", 20},
+		{"Subtitles", "N", 20},
+		{"Path", "O", 80},
 	},
 }
 
@@ -71,6 +78,8 @@ type MovieData struct {
 	Path           string
 	Overview       string
 	MovieId        string
+	MediaInfo
+	UserData
 }
 
 type MovieRow struct {
@@ -145,13 +154,19 @@ func (d *MovieRow) CellDataForSort(col int) string {
 	case 10:
 		return d.M.Resolution
 	case 11:
+		return d.M.QualityRank()
+	case 12:
+		return d.M.Audio()
+	case 13:
+		return d.M.Subtitles()
+	case 14:
 		return d.M.Path
 	default:
-		return ""
+		return GetUserDataField(col-MovieTableDescription.NoOfColumns, d.M.UserData)
 	}
 }
 
-func (d *MovieRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool) unison.Paneler {
+func (d *MovieRow) ColumnCell(_, col int, foreground, _ unison.Ink, selected, _, _ bool) unison.Paneler {
 	var text string
 	switch col {
 	case 0:
@@ -177,13 +192,19 @@ func (d *MovieRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool
 	case 10:
 		text = d.M.Resolution
 	case 11:
+		text = d.M.Quality()
+	case 12:
+		text = d.M.Audio()
+	case 13:
+		text = d.M.Subtitles()
+	case 14:
 		text = d.M.Path
 	default:
-		text = ""
+		text = GetUserDataField(col-MovieTableDescription.NoOfColumns, d.M.UserData)
 	}
 	wrapper := unison.NewPanel()
 	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
-	addText(wrapper, text, foreground, unison.LabelFont)
+	addText(wrapper, text, changedInk(d.M.MovieId, foreground, selected), unison.LabelFont)
 	return wrapper
 }
 
@@ -206,7 +227,7 @@ func NewMovieRow(id tid.TID, data MovieData) *MovieRow {
 		M: MovieData{data.Name, data.OriginalTitle, data.ProductionYear,
 			data.Runtime, data.Actors, data.Directors, data.Studios,
 			data.Genres, data.Container, data.Codecs, data.Resolution,
-			data.Path, data.Overview, data.MovieId},
+			data.Path, data.Overview, data.MovieId, data.MediaInfo, data.UserData},
 	}
 	return row
 }
@@ -236,9 +257,15 @@ func GetMovieDataField(index int, structure MovieData) string {
 	case 10:
 		return structure.Resolution
 	case 11:
+		return structure.Quality()
+	case 12:
+		return structure.Audio()
+	case 13:
+		return structure.Subtitles()
+	case 14:
 		return structure.Path
 	default:
-		return ""
+		return GetUserDataField(index-MovieTableDescription.NoOfColumns, structure.UserData)
 	}
 }
 
@@ -249,9 +276,11 @@ func GetMovieDataField(index int, structure MovieData) string {
 var _ unison.TableRowData[*TVShowRow] = &TVShowRow{}
 var TVShowTable *unison.Table[*TVShowRow]
 var TVShowTableDescription = TableDescription{
-	NoOfColumns: 12, //displayed columns only
+	NoOfColumns: 17, //displayed columns only
 	APIFields: "Name,MediaSources,Path,Genres,ProductionYear,People,Studios,Width,Height,Container,RunTimeTicks," +
-		"Overview,SeriesId,SeasonId,Id,ParentId,IndexNumber,Type_", //no spaces here!
+		"Overview,SeriesId,SeasonId,Id,ParentId,IndexNumber,IndexNumberEnd,ParentIndexNumber,LocationType,ProviderIds," +
+		"LockData,LockedFields,SortName,ForcedSortName,TagItems,OfficialRating," +
+		"DateCreated,DateLastSaved,Etag,Type_", //no spaces here!
 	Columns: []ColumnDescription{
 		{"Series", "A", 50},
 		{"Episode", "B", 50},
@@ -259,12 +288,17 @@ var TVShowTableDescription = TableDescription{
 		{"Year", "D", 10},
 		{"Time", "E", 10},
 		{"Actors", "F", 100},
-		{"Studio", "G", 30},
-		{"Genre", "H", 70},
-		{"Ext.", "I", 10},
-		{"Codec", "J", 20},
-		{"Resolution", "K", 15},
-		{"Path", "L", 80},
+		{"Writers", "G", 50},
+		{"Guest Stars", "H", 100},
+		{"Studio", "I", 30},
+		{"Genre", "J", 70},
+		{"Ext.", "K", 10},
+		{"Codec", "L", 20},
+		{"Resolution", "M", 15},
+		{"Quality", "N", 25},
+		{"Audio", "O", 20},
+		{"Subtitles", "P", 20},
+		{"Path", "Q", 80},
 	},
 }
 
@@ -275,6 +309,8 @@ type TVShowData struct {
 	ProductionYear string
 	Runtime        string
 	Actors         string
+	Writers        string
+	GuestStars     string
 	Studios        string
 	Genres         string
 	Container      string
@@ -287,6 +323,20 @@ type TVShowData struct {
 	EpisodeId      string
 	Type_          string
 	SortIndex      int32
+	MediaInfo
+	UserData
+}
+
+// Rows are series, seasons or episodes, only the id of the row's own type is set
+func (d TVShowData) itemId() string {
+	switch {
+	case d.EpisodeId != "":
+		return d.EpisodeId
+	case d.SeasonId != "":
+		return d.SeasonId
+	default:
+		return d.SeriesId
+	}
 }
 
 type TVShowRow struct {
@@ -340,7 +390,7 @@ func (d *TVShowRow) CellDataForSort(_ int) string {
 	return "" // Disable sorting for TV shows (would break dependencies between series and episodes)
 }
 
-func (d *TVShowRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool) unison.Paneler {
+func (d *TVShowRow) ColumnCell(_, col int, foreground, _ unison.Ink, selected, _, _ bool) unison.Paneler {
 	var text string
 	switch col {
 	case 0:
@@ -356,23 +406,33 @@ func (d *TVShowRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ boo
 	case 5:
 		text = d.M.Actors
 	case 6:
-		text = d.M.Studios
+		text = d.M.Writers
 	case 7:
-		text = d.M.Genres
+		text = d.M.GuestStars
 	case 8:
-		text = d.M.Container
+		text = d.M.Studios
 	case 9:
-		text = d.M.Codecs
+		text = d.M.Genres
 	case 10:
-		text = d.M.Resolution
+		text = d.M.Container
 	case 11:
+		text = d.M.Codecs
+	case 12:
+		text = d.M.Resolution
+	case 13:
+		text = d.M.Quality()
+	case 14:
+		text = d.M.Audio()
+	case 15:
+		text = d.M.Subtitles()
+	case 16:
 		text = d.M.Path
 	default:
-		text = ""
+		text = GetUserDataField(col-TVShowTableDescription.NoOfColumns, d.M.UserData)
 	}
 	wrapper := unison.NewPanel()
 	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
-	addText(wrapper, text, foreground, unison.LabelFont)
+	addText(wrapper, text, changedInk(d.M.itemId(), foreground, selected), unison.LabelFont)
 	return wrapper
 }
 
@@ -393,10 +453,10 @@ func NewTVShowRow(id tid.TID, data TVShowData) *TVShowRow {
 		parent:    nil,
 		children:  nil,
 		M: TVShowData{data.Name, data.Episode, data.Season, data.ProductionYear,
-			data.Runtime, data.Actors, data.Studios, data.Genres, data.Container,
+			data.Runtime, data.Actors, data.Writers, data.GuestStars, data.Studios, data.Genres, data.Container,
 			data.Codecs, data.Resolution, data.Path, data.Overview,
 			data.SeriesId, data.SeasonId, data.EpisodeId, data.Type_,
-			data.SortIndex},
+			data.SortIndex, data.MediaInfo, data.UserData},
 	}
 	return row
 }
@@ -416,19 +476,29 @@ func GetTVShowDataField(index int, structure TVShowData) string {
 	case 5:
 		return structure.Actors
 	case 6:
-		return structure.Studios
+		return structure.Writers
 	case 7:
-		return structure.Genres
+		return structure.GuestStars
 	case 8:
-		return structure.Container
+		return structure.Studios
 	case 9:
-		return structure.Codecs
+		return structure.Genres
 	case 10:
-		return structure.Resolution
+		return structure.Container
 	case 11:
+		return structure.Codecs
+	case 12:
+		return structure.Resolution
+	case 13:
+		return structure.Quality()
+	case 14:
+		return structure.Audio()
+	case 15:
+		return structure.Subtitles()
+	case 16:
 		return structure.Path
 	default:
-		return ""
+		return GetUserDataField(index-TVShowTableDescription.NoOfColumns, structure.UserData)
 	}
 }
 
@@ -439,8 +509,10 @@ func GetTVShowDataField(index int, structure TVShowData) string {
 var _ unison.TableRowData[*HomeVideoRow] = &HomeVideoRow{}
 var HomeVideoTable *unison.Table[*HomeVideoRow]
 var HomeVideoTableDescription = TableDescription{
-	NoOfColumns: 7,                                                                           //displayed columns only
-	APIFields:   "Name,MediaSources,Path,Width,Height,Container,RunTimeTicks,ParentId,Type_", //no spaces here!
+	NoOfColumns: 10, //displayed columns only
+	APIFields: "Name,MediaSources,Path,Width,Height,Container,RunTimeTicks,ParentId,LockData,LockedFields,Genres," +
+		"Studios,ProductionYear,SortName,ForcedSortName,TagItems,OfficialRating," +
+		"DateCreated,DateLastSaved,Etag,Type_", //no spaces here!
 	Columns: []ColumnDescription{
 		{"Title", "A", 100},
 		{"Folder", "B", 30},
@@ -448,7 +520,10 @@ var HomeVideoTableDescription = TableDescription{
 		{"Ext.", "D", 10},
 		{"Codec", "E", 20},
 		{"Resolution", "F", 15},
-		{"Path", "G", 150},
+		{"Quality", "G", 25},
+		{"Audio", "H", 20},
+		{"Subtitles", "I", 20},
+		{"Path", "J", 150},
 	},
 }
 
@@ -460,8 +535,18 @@ type HomeVideoData struct {
 	Codecs     string
 	Resolution string
 	Path       string
+	VideoId    string
 	FolderId   string
 	ParentId   string
+	MediaInfo
+	UserData
+}
+
+func (d HomeVideoData) itemId() string {
+	if d.FolderId != "" {
+		return d.FolderId
+	}
+	return d.VideoId
 }
 
 type HomeVideoRow struct {
@@ -526,13 +611,19 @@ func (d *HomeVideoRow) CellDataForSort(col int) string {
 	case 5:
 		return d.M.Resolution
 	case 6:
+		return d.M.QualityRank()
+	case 7:
+		return d.M.Audio()
+	case 8:
+		return d.M.Subtitles()
+	case 9:
 		return d.M.Path
 	default:
-		return ""
+		return GetUserDataField(col-HomeVideoTableDescription.NoOfColumns, d.M.UserData)
 	}
 }
 
-func (d *HomeVideoRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _ bool) unison.Paneler {
+func (d *HomeVideoRow) ColumnCell(_, col int, foreground, _ unison.Ink, selected, _, _ bool) unison.Paneler {
 	var text string
 	switch col {
 	case 0:
@@ -548,13 +639,19 @@ func (d *HomeVideoRow) ColumnCell(_, col int, foreground, _ unison.Ink, _, _, _
 	case 5:
 		text = d.M.Resolution
 	case 6:
+		text = d.M.Quality()
+	case 7:
+		text = d.M.Audio()
+	case 8:
+		text = d.M.Subtitles()
+	case 9:
 		text = d.M.Path
 	default:
-		text = ""
+		text = GetUserDataField(col-HomeVideoTableDescription.NoOfColumns, d.M.UserData)
 	}
 	wrapper := unison.NewPanel()
 	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
-	addText(wrapper, text, foreground, unison.LabelFont)
+	addText(wrapper, text, changedInk(d.M.itemId(), foreground, selected), unison.LabelFont)
 	return wrapper
 }
 
@@ -575,7 +672,7 @@ func NewHomeVideoRow(id tid.TID, data HomeVideoData) *HomeVideoRow {
 		parent:    nil,
 		children:  nil,
 		M: HomeVideoData{data.Name, data.Folder, data.Runtime, data.Container, data.Codecs,
-			data.Resolution, data.Path, data.FolderId, data.ParentId},
+			data.Resolution, data.Path, data.VideoId, data.FolderId, data.ParentId, data.MediaInfo, data.UserData},
 	}
 	return row
 }
@@ -595,10 +692,24 @@ func GetHomeVideoDataField(index int, structure HomeVideoData) string {
 	case 5:
 		return structure.Resolution
 	case 6:
+		return structure.Quality()
+	case 7:
+		return structure.Audio()
+	case 8:
+		return structure.Subtitles()
+	case 9:
 		return structure.Path
 	default:
-		return ""
+		return GetUserDataField(index-HomeVideoTableDescription.NoOfColumns, structure.UserData)
+	}
+}
+
+// Selected rows keep the ink of the selection
+func changedInk(id string, foreground unison.Ink, selected bool) unison.Ink {
+	if !selected && ChangedItems[id] {
+		return unison.ThemeFocus
 	}
+	return foreground
 }
 
 func addText(parent *unison.Panel, text string, ink unison.Ink, font unison.Font) {
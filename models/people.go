@@ -0,0 +1,29 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// People browser: people & filmography, displayed as lists (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var PeopleTableDescription = TableDescription{
+	NoOfColumns: 3,  //displayed columns only
+	APIFields:   "", //people are part of the items of all video views
+	Columns: []ColumnDescription{
+		{"Name", "A", 40},
+		{"Roles", "B", 30},
+		{"Items", "C", 10},
+	},
+}
+
+var FilmographyTableDescription = TableDescription{
+	NoOfColumns: 6,  //displayed columns only
+	APIFields:   "", //local items, or the items found by the server
+	Columns: []ColumnDescription{
+		{"Title", "A", 60},
+		{"Type", "B", 10},
+		{"Year", "C", 10},
+		{"Credits", "D", 40},
+		{"View", "E", 20},
+		{"Path", "F", 100},
+	},
+}
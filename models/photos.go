@@ -0,0 +1,197 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Data model for Emby Photos (folder tree with EXIF metadata), according to Unison's table model
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/fatal"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+	"time"
+)
+
+var PhotoDataTable []PhotoData
+
+var _ unison.TableRowData[*PhotoRow] = &PhotoRow{}
+var PhotoTable *unison.Table[*PhotoRow]
+var PhotoTableDescription = TableDescription{
+	NoOfColumns: 15, //displayed columns only
+	APIFields: "Name,Path,Width,Height,DateCreated,PremiereDate,CameraMake,CameraModel,ExposureTime,FocalLength," +
+		"Aperture,IsoSpeedRating,ImageOrientation,Latitude,Longitude,Altitude,ParentId,DateLastSaved,Etag," +
+		"Type_", //no spaces here!
+	Columns: []ColumnDescription{
+		{"Title", "A", 50},
+		{"Folder", "B", 30},
+		{"Taken", "C", 20},
+		{"Make", "D", 20},
+		{"Model", "E", 25},
+		{"Exposure", "F", 12},
+		{"Focal length", "G", 12},
+		{"Aperture", "H", 10},
+		{"ISO", "I", 8},
+		{"Resolution", "J", 15},
+		{"Orientation", "K", 15},
+		{"Latitude", "L", 15},
+		{"Longitude", "M", 15},
+		{"Altitude", "N", 10},
+		{"Path", "O", 100},
+	},
+}
+
+type PhotoData struct {
+	Name        string
+	Folder      string
+	DateTaken   string
+	Taken       time.Time // DateTaken as is, for the waypoints
+	CameraMake  string
+	CameraModel string
+	Exposure    string
+	FocalLength string
+	Aperture    string
+	IsoSpeed    string
+	Resolution  string
+	Orientation string
+	Latitude    string
+	Longitude   string
+	Altitude    string
+	Path        string
+	PhotoId     string
+	FolderId    string
+	ParentId    string
+	IsFolder    bool
+	UserData
+}
+
+func (d PhotoData) itemId() string {
+	if d.IsFolder {
+		return d.FolderId
+	}
+	return d.PhotoId
+}
+
+type PhotoRow struct {
+	table        *unison.Table[*PhotoRow]
+	parent       *PhotoRow
+	children     []*PhotoRow
+	container    bool
+	open         bool
+	doubleHeight bool
+	id           tid.TID
+	M            PhotoData
+}
+
+func (d *PhotoRow) CloneForTarget(target unison.Paneler, newParent *PhotoRow) *PhotoRow {
+	table, ok := target.(*unison.Table[*PhotoRow])
+	if !ok {
+		fatal.IfErr(errs.New("invalid target"))
+	}
+	clone := *d
+	clone.table = table
+	clone.parent = newParent
+	clone.id = tid.MustNewTID('a')
+	return &clone
+}
+
+func (d *PhotoRow) ID() tid.TID {
+	return d.id
+}
+
+func (d *PhotoRow) Parent() *PhotoRow {
+	return d.parent
+}
+
+func (d *PhotoRow) SetParent(parent *PhotoRow) {
+	d.parent = parent
+}
+
+func (d *PhotoRow) CanHaveChildren() bool {
+	return d.container
+}
+
+func (d *PhotoRow) Children() []*PhotoRow {
+	return d.children
+}
+
+func (d *PhotoRow) SetChildren(children []*PhotoRow) {
+	d.children = children
+}
+
+func (d *PhotoRow) AddChild(child *PhotoRow) {
+	child.parent = d
+	d.children = append(d.children, child)
+}
+
+func (d *PhotoRow) CellDataForSort(col int) string {
+	return GetPhotoDataField(col, d.M)
+}
+
+func (d *PhotoRow) ColumnCell(_, col int, foreground, _ unison.Ink, selected, _, _ bool) unison.Paneler {
+	text := GetPhotoDataField(col, d.M)
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
+	addText(wrapper, text, changedInk(d.M.itemId(), foreground, selected), unison.LabelFont)
+	return wrapper
+}
+
+func (d *PhotoRow) IsOpen() bool {
+	return d.open
+}
+
+func (d *PhotoRow) SetOpen(open bool) {
+	d.open = open
+}
+
+func NewPhotoRow(id tid.TID, data PhotoData) *PhotoRow {
+	row := &PhotoRow{
+		table:     PhotoTable,
+		id:        id,
+		container: data.IsFolder,
+		open:      data.IsFolder,
+		parent:    nil,
+		children:  nil,
+		M:         data,
+	}
+	return row
+}
+
+func GetPhotoDataField(index int, structure PhotoData) string {
+	switch index {
+	case 0:
+		return structure.Name
+	case 1:
+		return structure.Folder
+	case 2:
+		return structure.DateTaken
+	case 3:
+		return structure.CameraMake
+	case 4:
+		return structure.CameraModel
+	case 5:
+		return structure.Exposure
+	case 6:
+		return structure.FocalLength
+	case 7:
+		return structure.Aperture
+	case 8:
+		return structure.IsoSpeed
+	case 9:
+		return structure.Resolution
+	case 10:
+		return structure.Orientation
+	case 11:
+		return structure.Latitude
+	case 12:
+		return structure.Longitude
+	case 13:
+		return structure.Altitude
+	case 14:
+		return structure.Path
+	default:
+		return GetUserDataField(index-PhotoTableDescription.NoOfColumns, structure.UserData)
+	}
+}
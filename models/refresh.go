@@ -0,0 +1,17 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata refresh progress, displayed as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var RefreshTableDescription = TableDescription{
+	NoOfColumns: 4,  //displayed columns only
+	APIFields:   "", //items are fetched one at a time
+	Columns: []ColumnDescription{
+		{"Title", "A", 50},
+		{"Type", "B", 15},
+		{"Option", "C", 30},
+		{"Status", "D", 40},
+	},
+}
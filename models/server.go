@@ -0,0 +1,52 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Server information, libraries, scheduled tasks & the activity log, displayed as lists (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var ServerInfoTableDescription = TableDescription{
+	NoOfColumns: 2,  //displayed columns only
+	APIFields:   "", //system info is not queried by fields
+	Columns: []ColumnDescription{
+		{"Property", "A", 25},
+		{"Value", "B", 60},
+	},
+}
+
+var LibraryTableDescription = TableDescription{
+	NoOfColumns: 4,  //displayed columns only
+	APIFields:   "", //libraries are not queried by fields
+	Columns: []ColumnDescription{
+		{"Library", "A", 30},
+		{"Type", "B", 15},
+		{"Paths", "C", 80},
+		{"Scan", "D", 15},
+	},
+}
+
+var TaskTableDescription = TableDescription{
+	NoOfColumns: 7,  //displayed columns only
+	APIFields:   "", //tasks are not queried by fields
+	Columns: []ColumnDescription{
+		{"Task", "A", 40},
+		{"Category", "B", 20},
+		{"State", "C", 12},
+		{"Progress", "D", 10},
+		{"Last run", "E", 20},
+		{"Last result", "F", 40},
+		{"Next run", "G", 20},
+	},
+}
+
+var ActivityTableDescription = TableDescription{
+	NoOfColumns: 5,  //displayed columns only
+	APIFields:   "", //entries are not queried by fields
+	Columns: []ColumnDescription{
+		{"Date", "A", 20},
+		{"Severity", "B", 10},
+		{"Type", "C", 25},
+		{"Event", "D", 60},
+		{"Details", "E", 80},
+	},
+}
@@ -0,0 +1,28 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Active sessions & now playing, displayed as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var SessionTableDescription = TableDescription{
+	NoOfColumns: 15, //displayed columns only
+	APIFields:   "", //sessions are not queried by fields
+	Columns: []ColumnDescription{
+		{"User", "A", 20},
+		{"Client", "B", 25},
+		{"Device", "C", 25},
+		{"Now playing", "D", 50},
+		{"Progress", "E", 10},
+		{"State", "F", 10},
+		{"Play method", "G", 15},
+		{"Transcode reasons", "H", 50},
+		{"Video", "I", 20},
+		{"Audio", "J", 20},
+		{"Bitrate", "K", 12},
+		{"Hardware", "L", 25},
+		{"CPU", "M", 8},
+		{"Remote address", "N", 20},
+		{"Last activity", "O", 20},
+	},
+}
@@ -0,0 +1,69 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Library statistics of a view, displayed as charts or as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+import "strconv"
+
+var StatisticsTableDescription = TableDescription{
+	NoOfColumns: 4,  //displayed columns only
+	APIFields:   "", //computed from the items fetched
+	Columns: []ColumnDescription{
+		{"Category", "A", 30},
+		{"Value", "B", 40},
+		{"Count", "C", 10},
+		{"Share", "D", 10},
+	},
+}
+
+type StatisticsEntry struct {
+	Name  string
+	Count int
+}
+
+// StatisticsGroup entries are sorted by count, descending
+type StatisticsGroup struct {
+	Title   string
+	Entries []StatisticsEntry
+}
+
+type Statistics struct {
+	Items   int
+	Runtime string
+	Size    string
+	Groups  []StatisticsGroup
+}
+
+const (
+	statTotals  = "Totals"
+	statItems   = "Items"
+	statRuntime = "Total runtime"
+	statSize    = "Total size"
+)
+
+// ListData totals first, then one parent row per group, the share is relative to the number of items
+func (s Statistics) ListData() []ListData {
+	result := make([]ListData, 0)
+	result = append(result, ListData{
+		Fields: []string{statTotals, "", "", ""},
+		Children: []ListData{
+			{Fields: []string{"", statItems, strconv.Itoa(s.Items), ""}},
+			{Fields: []string{"", statRuntime, s.Runtime, ""}},
+			{Fields: []string{"", statSize, s.Size, ""}},
+		},
+	})
+	for _, g := range s.Groups {
+		group := ListData{Fields: []string{g.Title, "", "", ""}}
+		for _, e := range g.Entries {
+			share := ""
+			if s.Items > 0 {
+				share = strconv.FormatFloat(float64(e.Count)*100/float64(s.Items), 'f', 1, 64) + "%"
+			}
+			group.Children = append(group.Children, ListData{Fields: []string{"", e.Name, strconv.Itoa(e.Count), share}})
+		}
+		result = append(result, group)
+	}
+	return result
+}
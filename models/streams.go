@@ -0,0 +1,29 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Media sources & streams of an item (details window), displayed as a list (see list.go)
+// Sources are parent rows, their streams are the children
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var StreamTableDescription = TableDescription{
+	NoOfColumns: 15, //displayed columns only
+	APIFields:   "", //streams are part of MediaSources
+	Columns: []ColumnDescription{
+		{"Stream", "A", 25},
+		{"Codec", "B", 12},
+		{"Profile", "C", 15},
+		{"Level", "D", 8},
+		{"Bit depth", "E", 8},
+		{"Range", "F", 20},
+		{"Resolution", "G", 12},
+		{"Frame rate", "H", 10},
+		{"Channels", "I", 8},
+		{"Layout", "J", 12},
+		{"Bitrate", "K", 12},
+		{"Language", "L", 12},
+		{"Flags", "M", 15},
+		{"Location", "N", 10},
+		{"Title", "O", 50},
+	},
+}
@@ -0,0 +1,87 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Watch state of the current user (optional columns & filter), shared by all data models
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+// Optional columns, appended to the displayed columns of every table (XLS columns are assigned on export)
+var UserDataColumns = []ColumnDescription{
+	{"Played", "", 8},
+	{"Plays", "", 8},
+	{"Last played", "", 20},
+	{"Progress", "", 10},
+	{"Favourite", "", 10},
+}
+
+// Value of the yes/no columns, e.g. played & favourite
+const FlagYes = "Yes"
+
+type UserData struct {
+	Played     string
+	PlayCount  string
+	LastPlayed string
+	Progress   string
+	Favorite   string
+	IsPlayed   bool
+	InProgress bool
+	IsFavorite bool
+}
+
+type WatchFilter int
+
+const (
+	WatchAll WatchFilter = iota
+	WatchUnplayed
+	WatchInProgress
+	WatchFavorites
+)
+
+func (u UserData) Matches(filter WatchFilter) bool {
+	switch filter {
+	case WatchUnplayed:
+		return !u.IsPlayed
+	case WatchInProgress:
+		return u.InProgress
+	case WatchFavorites:
+		return u.IsFavorite
+	default:
+		return true
+	}
+}
+
+// SetPlayed anticipates the server's answer, marking an item (un)played also resets its playback position
+func (u *UserData) SetPlayed(played bool) {
+	u.IsPlayed = played
+	u.Played = ""
+	if played {
+		u.Played = FlagYes
+	}
+	u.InProgress = false
+	u.Progress = ""
+}
+
+func (u *UserData) SetFavorite(favorite bool) {
+	u.IsFavorite = favorite
+	u.Favorite = ""
+	if favorite {
+		u.Favorite = FlagYes
+	}
+}
+
+func GetUserDataField(index int, structure UserData) string {
+	switch index {
+	case 0:
+		return structure.Played
+	case 1:
+		return structure.PlayCount
+	case 2:
+		return structure.LastPlayed
+	case 3:
+		return structure.Progress
+	case 4:
+		return structure.Favorite
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,26 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Users of the server with their access rights, displayed as a list (see list.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package models
+
+var UserTableDescription = TableDescription{
+	NoOfColumns: 13, //displayed columns only
+	APIFields:   "", //users are not queried by fields
+	Columns: []ColumnDescription{
+		{"User", "A", 25},
+		{"Administrator", "B", 12},
+		{"Disabled", "C", 10},
+		{"Hidden", "D", 10},
+		{"Last login", "E", 20},
+		{"Last activity", "F", 20},
+		{"Libraries", "G", 60},
+		{"Max. rating", "H", 15},
+		{"Blocked tags", "I", 30},
+		{"Remote access", "J", 12},
+		{"Remote bitrate limit", "K", 15},
+		{"Live TV", "L", 10},
+		{"Deletion", "M", 10},
+	},
+}
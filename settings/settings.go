@@ -15,6 +15,10 @@ type Settings struct {
 	EmbyUser         string
 	EmbyPassword     []byte
 	LastExportFolder string
+	ShowUserData     bool
+	DisabledRules    []string
+	PeopleLimits     map[string]int
+	ShowFacets       bool
 }
 
 var settings Settings
@@ -48,3 +52,35 @@ func Valid() bool {
 	return settings.EmbyServer != "" && settings.EmbyPort != "" && settings.EmbyUser != "" && len(settings.EmbyPassword) > 0 &&
 		settings.WindowRect.Width > 0 && settings.WindowRect.Height > 0
 }
+
+func SetShowUserData(show bool) {
+	settings.ShowUserData = show
+}
+
+func GetShowUserData() bool {
+	return settings.ShowUserData
+}
+
+func SetShowFacets(show bool) {
+	settings.ShowFacets = show
+}
+
+func GetShowFacets() bool {
+	return settings.ShowFacets
+}
+
+func SetDisabledRules(ids []string) {
+	settings.DisabledRules = ids
+}
+
+func GetDisabledRules() []string {
+	return settings.DisabledRules
+}
+
+func SetPeopleLimits(limits map[string]int) {
+	settings.PeopleLimits = limits
+}
+
+func GetPeopleLimits() map[string]int {
+	return settings.PeopleLimits
+}
@@ -0,0 +1,225 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Offline snapshots of the views fetched, stored per server, user & view in the user's cache directory
+// The latest snapshots of a view are kept (see Kept), each one named by the time it was taken (UTC)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package snapshot
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	rootDirName   = "snapshots"
+	viewsFileName = "views.json"
+	fileExtension = ".json"
+	timeFormat    = "20060102-150405"
+	dayFormat     = "20060102"
+)
+
+// Kept is the number of snapshots kept per view; beyond those, the last snapshot of a day is kept for KeptDays,
+// so that a view can be compared to the week before (see api.DiffItems)
+const (
+	Kept     = 5
+	KeptDays = 31
+)
+
+var ErrNoSnapshot = errors.New("no snapshot")
+
+// Key identifies the server & user the snapshots belong to
+type Key struct {
+	Server string // host & port as configured
+	User   string
+}
+
+type Snapshot struct {
+	Key
+	View     api.UserView
+	Taken    time.Time
+	LastFull time.Time // of the last full fetch, items deleted since are still included
+	Items    []api.BaseItemDto
+}
+
+func NewKey(server string, port string, user string) Key {
+	return Key{Server: server + "_" + port, User: user}
+}
+
+func (k Key) dir() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cache, assets.AppName, rootDirName, safeName(k.Server), safeName(strings.ToLower(k.User))), nil
+}
+
+func (k Key) viewDir(viewId string) (string, error) {
+	dir, err := k.dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, safeName(viewId)), nil
+}
+
+// SaveViews keeps the views of the user, so that they can be browsed before authentication
+func SaveViews(key Key, views []api.UserView) error {
+	dir, err := key.dir()
+	if err != nil {
+		return err
+	}
+	return writeJSON(dir, viewsFileName, views)
+}
+
+func LoadViews(key Key) ([]api.UserView, error) {
+	var views []api.UserView
+	dir, err := key.dir()
+	if err != nil {
+		return nil, err
+	}
+	err = readJSON(filepath.Join(dir, viewsFileName), &views)
+	return views, err
+}
+
+// Save stores a new snapshot of the view, older snapshots are removed (see Kept)
+func Save(s Snapshot) error {
+	dir, err := s.Key.viewDir(s.View.Id)
+	if err != nil {
+		return err
+	}
+	if err = writeJSON(dir, s.Taken.UTC().Format(timeFormat)+fileExtension, s); err != nil {
+		return err
+	}
+	files, err := snapshotFiles(dir)
+	if err != nil {
+		return err
+	}
+	oldest := s.Taken.AddDate(0, 0, -KeptDays)
+	days := make(map[string]bool)
+	for i, f := range files {
+		taken, _ := takenOf(f)
+		day := taken.Format(dayFormat)
+		if i >= Kept && (days[day] || taken.Before(oldest)) {
+			_ = os.Remove(f)
+		}
+		days[day] = true
+	}
+	return nil
+}
+
+// Latest loads the newest snapshot of the view, ErrNoSnapshot if there is none
+func Latest(key Key, viewId string) (Snapshot, error) {
+	var s Snapshot
+	dir, err := key.viewDir(viewId)
+	if err != nil {
+		return s, err
+	}
+	files, err := snapshotFiles(dir)
+	if err != nil || len(files) == 0 {
+		return s, ErrNoSnapshot
+	}
+	err = readJSON(files[0], &s)
+	return s, err
+}
+
+// History returns the times the snapshots of the view were taken, newest first
+func History(key Key, viewId string) ([]time.Time, error) {
+	dir, err := key.viewDir(viewId)
+	if err != nil {
+		return nil, err
+	}
+	files, err := snapshotFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]time.Time, 0, len(files))
+	for _, f := range files {
+		taken, _ := takenOf(f)
+		result = append(result, taken)
+	}
+	return result, nil
+}
+
+// Load loads the snapshot of the view taken at the time given (see History)
+func Load(key Key, viewId string, taken time.Time) (Snapshot, error) {
+	var s Snapshot
+	dir, err := key.viewDir(viewId)
+	if err != nil {
+		return s, err
+	}
+	err = readJSON(filepath.Join(dir, taken.UTC().Format(timeFormat)+fileExtension), &s)
+	if os.IsNotExist(err) {
+		err = ErrNoSnapshot
+	}
+	return s, err
+}
+
+// The time a snapshot was taken is its name, false if the file isn't a snapshot
+func takenOf(path string) (time.Time, bool) {
+	taken, err := time.Parse(timeFormat, strings.TrimSuffix(filepath.Base(path), fileExtension))
+	return taken, err == nil
+}
+
+// snapshotFiles returns the snapshots of a view directory, newest first; other files are left out
+func snapshotFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if _, ok := takenOf(path); ok && !e.IsDir() {
+			files = append(files, path)
+		}
+	}
+	// the names are timestamps, they sort by time
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	return files, nil
+}
+
+func writeJSON(dir string, name string, v any) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// written to a temporary file first, a snapshot is never left half written
+	tmp := filepath.Join(dir, name+".tmp")
+	if err = os.WriteFile(tmp, j, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, name))
+}
+
+func readJSON(path string, v any) error {
+	j, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(j, v)
+}
+
+// Names from the server or the preferences may contain characters not allowed in file names
+func safeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		default:
+			return r
+		}
+	}, name)
+}
@@ -0,0 +1,191 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Library access windows (administrators only): users × libraries matrix, and the users able to see an item
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"strconv"
+)
+
+const (
+	accessWindowWidth      float32 = 1200
+	accessWindowHeight     float32 = 500
+	itemAccessWindowWidth  float32 = 1000
+	itemAccessWindowHeight float32 = 400
+)
+
+var accessWindow *unison.Window
+var accessDesc models.TableDescription
+var accessData []models.ListData
+
+var itemAccessWindow *unison.Window
+var itemAccessTable *unison.Table[*models.ListRow]
+var itemAccessStatus *unison.Label
+var itemAccessItemId string
+var itemAccessData []models.ListData
+
+// The users, libraries & ratings as needed by both windows, fetched in the background
+type accessSources struct {
+	users   []api.UserDto
+	folders []api.VirtualFolderInfo
+	ratings []api.ParentalRating
+	err     error
+}
+
+func fetchAccessSources() accessSources {
+	var s accessSources
+	s.users, s.err = api.GetUsersInt()
+	if s.err == nil {
+		s.folders, s.err = api.GetVirtualFoldersInt()
+	}
+	// without the ratings, rating values are shown instead of names
+	s.ratings, _ = api.GetParentalRatingsInt()
+	return s
+}
+
+// A column per library, inserted after the user
+func accessMatrixDescription(libraries []string) models.TableDescription {
+	columns := []models.ColumnDescription{models.AccessMatrixColumns[0]}
+	for _, l := range libraries {
+		columns = append(columns, models.ColumnDescription{Caption: l, XLSColumnWidth: models.AccessLibraryColumnWidth})
+	}
+	columns = append(columns, models.AccessMatrixColumns[1:]...)
+	for i := range columns {
+		columns[i].XLSColumn = export.ColumnName(i + 1)
+	}
+	return models.TableDescription{NoOfColumns: len(columns), Columns: columns}
+}
+
+func accessWindowDisplay() {
+	if accessWindow != nil {
+		accessWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapLibraryAccess, accessWindowWidth, accessWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	accessWindow = wnd
+	accessDesc, accessData = accessMatrixDescription(nil), nil
+	_, scrollArea := newListTable(accessDesc, accessData)
+	toolbar, status := newListToolbar()
+	var refreshBtn *unison.Button
+	fetch := func() {
+		refreshBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go func() {
+			s := fetchAccessSources()
+			data := api.GetAccessMatrixDisplayData(s.users, s.folders, s.ratings)
+			desc := accessMatrixDescription(api.SortLibraries(s.folders))
+			unison.InvokeTask(func() {
+				if accessWindow != wnd {
+					return
+				}
+				refreshBtn.SetEnabled(true)
+				if s.err != nil {
+					status.SetTitle(assets.ErrFetchUsers + " " + s.err.Error())
+					status.Parent().MarkForLayoutAndRedraw()
+					return
+				}
+				// the columns depend on the libraries, so the table is replaced
+				accessDesc, accessData = desc, data
+				content := wnd.Content()
+				content.RemoveChild(scrollArea)
+				_, scrollArea = newListTable(accessDesc, accessData)
+				content.AddChild(scrollArea)
+				status.SetTitle(assets.CapUsers + ": " + strconv.Itoa(len(accessData)) + "   " +
+					assets.CapLibraries + ": " + strconv.Itoa(len(s.folders)))
+				content.MarkForLayoutAndRedraw()
+			})
+		}()
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, fetch)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(accessDesc, accessData, assets.CapLibraryAccess)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		accessWindow = nil
+	}
+	wnd.ToFront()
+	fetch()
+}
+
+func canShowItemAccess() bool {
+	return api.IsAdministratorInt() && canEditMetadata()
+}
+
+// Users able to see the item selected; the window is reused for the next item
+func itemAccessDisplay() {
+	d, ok := selectedEditItem()
+	if !ok {
+		return
+	}
+	var parentRating string
+	if series, ok := itemCache[d.SeriesId]; ok {
+		parentRating = series.OfficialRating
+	}
+	libraryId := userViews[viewsPopupMenu.SelectedIndex()].Id
+	itemAccessItemId = d.Id
+	if itemAccessWindow == nil {
+		wnd, err := newListWindow(assets.CapItemAccess, itemAccessWindowWidth, itemAccessWindowHeight)
+		if err != nil {
+			DialogToDisplaySystemError(assets.CapError, err)
+			return
+		}
+		itemAccessWindow = wnd
+		itemAccessData = nil
+		table, scrollArea := newListTable(models.ItemAccessTableDescription, itemAccessData)
+		itemAccessTable = table
+		toolbar, status := newListToolbar()
+		itemAccessStatus = status
+		addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+			exportList(models.ItemAccessTableDescription, itemAccessData, assets.CapItemAccess)
+		})
+		content := wnd.Content()
+		content.AddChild(toolbar)
+		content.AddChild(scrollArea)
+		wnd.WillCloseCallback = func() {
+			itemAccessWindow = nil
+			itemAccessTable = nil
+			itemAccessStatus = nil
+		}
+	}
+	wnd := itemAccessWindow
+	title := api.ItemTitle(d)
+	wnd.SetTitle(assets.AppName + " - " + assets.CapItemAccess + " - " + title)
+	itemAccessStatus.SetTitle(assets.CapSearching)
+	itemAccessStatus.Parent().MarkForLayoutAndRedraw()
+	wnd.ToFront()
+	go func() {
+		s := fetchAccessSources()
+		data := api.GetItemAccessDisplayData(d, parentRating, libraryId, s.users, s.folders, s.ratings)
+		unison.InvokeTask(func() {
+			// a later item may have been chosen meanwhile
+			if itemAccessWindow != wnd || itemAccessItemId != d.Id {
+				return
+			}
+			if s.err != nil {
+				itemAccessStatus.SetTitle(assets.ErrFetchUsers + " " + s.err.Error())
+			} else {
+				itemAccessData = data
+				setListRows(itemAccessTable, itemAccessData)
+				itemAccessStatus.SetTitle(title)
+				itemAccessTable.MarkForRedraw()
+			}
+			itemAccessStatus.Parent().MarkForLayoutAndRedraw()
+		})
+	}()
+}
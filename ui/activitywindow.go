@@ -0,0 +1,120 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Activity log window: logins, playback, errors etc. as logged by the server, filtered by date & type
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"strconv"
+	"time"
+)
+
+const (
+	activityWindowWidth  float32 = 1300
+	activityWindowHeight float32 = 600
+	activityLogLimit             = 5000
+)
+
+// Periods of the date filter, zero is the whole log
+var activityPeriods = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour, 0}
+
+var activityWindow *unison.Window
+var activityEntries []api.ActivityLogEntry
+var activityData []models.ListData
+
+func activityWindowDisplay() {
+	if activityWindow != nil {
+		activityWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapActivityLog, activityWindowWidth, activityWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	activityWindow = wnd
+	activityEntries, activityData = nil, nil
+	table, scrollArea := newListTable(models.ActivityTableDescription, activityData)
+	toolbar, status := newListToolbar()
+	var refreshBtn *unison.Button
+	periodPopup := addListPopup(toolbar, assets.CapLast24Hours, assets.CapLast7Days, assets.CapLast30Days,
+		assets.CapWholeLog)
+	typePopup := addListPopup(toolbar, assets.CapAllTypes)
+	entryType := func() string {
+		if typePopup.SelectedIndex() <= 0 {
+			return ""
+		}
+		t, _ := typePopup.Selected()
+		return t
+	}
+	update := func() {
+		activityData = api.GetActivityDisplayData(activityEntries, entryType())
+		setListRows(table, activityData)
+		status.SetTitle(assets.CapEntries + ": " + strconv.Itoa(len(activityData)) + " / " +
+			strconv.Itoa(len(activityEntries)))
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	}
+	// the types offered are those of the entries fetched, the type selected is kept if still present
+	updateTypes := func() {
+		selected := entryType()
+		callback := typePopup.SelectionChangedCallback
+		typePopup.SelectionChangedCallback = nil
+		typePopup.RemoveAllItems()
+		typePopup.AddItem(assets.CapAllTypes)
+		typePopup.AddItem(api.ActivityTypes(activityEntries)...)
+		typePopup.SelectIndex(0)
+		if selected != "" {
+			typePopup.Select(selected)
+		}
+		typePopup.SelectionChangedCallback = callback
+		typePopup.MarkForLayoutAndRedraw()
+	}
+	fetch := func() {
+		var minDate time.Time
+		if period := activityPeriods[periodPopup.SelectedIndex()]; period > 0 {
+			minDate = time.Now().Add(-period)
+		}
+		refreshBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go func() {
+			result, err := api.GetActivityLogInt(minDate, activityLogLimit)
+			unison.InvokeTask(func() {
+				if activityWindow != wnd {
+					return
+				}
+				refreshBtn.SetEnabled(true)
+				if err != nil {
+					status.SetTitle(assets.ErrFetchActivity + " " + err.Error())
+					status.Parent().MarkForLayoutAndRedraw()
+					return
+				}
+				activityEntries = result.Items
+				updateTypes()
+				update()
+			})
+		}()
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, fetch)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.ActivityTableDescription, activityData, assets.CapActivityLog)
+	})
+	periodPopup.SelectionChangedCallback = func(*unison.PopupMenu[string]) { fetch() }
+	typePopup.SelectionChangedCallback = func(*unison.PopupMenu[string]) { update() }
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		activityWindow = nil
+		activityEntries = nil
+	}
+	wnd.ToFront()
+	fetch()
+}
@@ -0,0 +1,150 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Bulk metadata edits via a spreadsheet: the view is exported with hidden item ids, edited in Excel and read back
+// Values differing from the server's are staged as edits for review (see metadataedit.go)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
+	"Emby_Explorer/models"
+	"Emby_Explorer/settings"
+	"github.com/richardwilkes/unison"
+	"os"
+	"strconv"
+)
+
+const bulkEditIdColumn = 0
+
+// Field edited in each column of models.BulkEditTableDescription, columns without a field are informational
+var bulkEditFields = []string{"", "", "", api.FieldName, api.FieldSortName, api.FieldOriginalTitle,
+	api.FieldProductionYear, api.FieldGenres, api.FieldTags, api.FieldOfficialRating, ""}
+
+func canExportBulkEdit() bool {
+	switch collectionType {
+	case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos:
+		return len(itemList) > 0
+	default:
+		return false
+	}
+}
+
+func bulkEditItems() []api.BaseItemDto {
+	result := make([]api.BaseItemDto, 0)
+	for _, d := range itemList {
+		switch d.Type_ {
+		case api.MovieType, api.SeriesType, api.SeasonType, api.EpisodeType, api.VideoType:
+			result = append(result, d)
+		default:
+		}
+	}
+	return result
+}
+
+func bulkEditExport() {
+	items := bulkEditItems()
+	desc := models.BulkEditTableDescription
+	hdr, exp := buildExportData(desc.Columns[:desc.NoOfColumns], len(items), func(int) bool { return true },
+		func(row, col int) string {
+			d := items[row]
+			switch {
+			case col == bulkEditIdColumn:
+				return d.Id
+			case col == 1:
+				return d.Type_
+			case col == 2:
+				return d.SeriesName
+			case bulkEditFields[col] != "":
+				return api.EditableValue(d, bulkEditFields[col])
+			default:
+				return d.Path
+			}
+		})
+	hdr[bulkEditIdColumn].Hidden = true
+	sheet := assets.CapBulkEdit
+	if p, ok := runExportDialog(sheet, assets.FileExtension); ok {
+		if err := export.XlsxExport(exp, hdr, p, sheet); err != nil {
+			DialogToDisplaySystemError(assets.CapError, err)
+		}
+	}
+}
+
+func bulkEditImport() {
+	dialog := unison.NewOpenDialog()
+	folder := settings.GetLastExportFolder()
+	if folder == "" {
+		folder, _ = os.UserHomeDir()
+	}
+	dialog.SetInitialDirectory(folder)
+	dialog.SetAllowedExtensions(assets.FileExtension)
+	dialog.SetAllowsMultipleSelection(false)
+	if !dialog.RunModal() {
+		return
+	}
+	columns := models.BulkEditTableDescription.Columns
+	rows, err := export.XlsxImport(dialog.Paths()[0], columns[bulkEditIdColumn].Caption)
+	if err != nil {
+		DialogToDisplaySystemError(assets.ErrImportFailed, err)
+		return
+	}
+	go diffBulkEdit(rows)
+}
+
+// Compares every row with the server's copy of the item (in the background), differences are staged
+func diffBulkEdit(rows []map[string]string) {
+	columns := models.BulkEditTableDescription.Columns
+	var edits []models.MetadataEdit
+	var locked, invalid, failed int
+	for _, row := range rows {
+		id := row[columns[bulkEditIdColumn].Caption]
+		if id == "" {
+			continue
+		}
+		live, err := api.UserGetItemInt(id)
+		if err != nil {
+			failed++
+			continue
+		}
+		for col, field := range bulkEditFields {
+			value, ok := row[columns[col].Caption]
+			if field == "" || !ok {
+				continue
+			}
+			value = api.NormalizeValue(field, value)
+			current := api.EditableValue(live, field)
+			if value == current {
+				continue
+			}
+			if api.FieldLocked(live, field) {
+				locked++
+				continue
+			}
+			if api.ValidateValue(field, value) != nil {
+				invalid++
+				continue
+			}
+			edits = append(edits, models.MetadataEdit{
+				ItemId:  id,
+				Title:   api.ItemTitle(live),
+				Field:   field,
+				Caption: fieldCaptions[field],
+				Old:     current,
+				New:     value,
+			})
+		}
+	}
+	unison.InvokeTask(func() {
+		for _, e := range edits {
+			stageEdit(e)
+		}
+		editsWindowDisplay()
+		if locked+invalid+failed > 0 {
+			DialogToDisplayErrorMessage(assets.ErrImportIncomplete,
+				assets.CapLocked+": "+strconv.Itoa(locked)+"   "+assets.CapInvalid+": "+strconv.Itoa(invalid)+"   "+
+					assets.CapNotFound+": "+strconv.Itoa(failed))
+		}
+	})
+}
@@ -13,11 +13,15 @@ import (
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/behavior"
+	"strings"
 )
 
 const (
-	textPanelWidth  float32 = 500
-	textPanelHeight float32 = 200
+	textPanelWidth    float32 = 500
+	textPanelHeight   float32 = 200
+	streamPanelWidth  float32 = 900
+	streamPanelHeight float32 = 220
+	castPanelHeight   float32 = 180
 )
 
 var detailsWindow *unison.Window
@@ -77,7 +81,7 @@ func detailsWindowDisplay() {
 
 func newContentPanel() (*unison.Panel, bool, bool) {
 	panel := unison.NewPanel()
-	var ovw string
+	var ovw, itemId string
 	var img *unison.Image
 	var pl, pr = false, false
 	if collectionType != "" {
@@ -87,6 +91,7 @@ func newContentPanel() (*unison.Panel, bool, bool) {
 			for _, m := range movie {
 				img, _ = newImageFromBytes(m.M.MovieId)
 				ovw = m.M.Overview
+				itemId = m.M.MovieId
 				break
 			}
 		case api.CollectionTVShows:
@@ -94,6 +99,33 @@ func newContentPanel() (*unison.Panel, bool, bool) {
 			for _, t := range tvshow {
 				img, _ = newImageFromBytes(t.M.SeasonId)
 				ovw = t.M.Overview
+				itemId = tvShowItemId(&t.M)
+				break
+			}
+		case api.CollectionHomeVideos:
+			video := models.HomeVideoTable.SelectedRows(true)
+			for _, h := range video {
+				img, _ = newImageFromBytes(h.M.VideoId)
+				itemId = h.M.VideoId
+				break
+			}
+		case api.CollectionPhotos:
+			photo := models.PhotoTable.SelectedRows(true)
+			for _, p := range photo {
+				if !p.M.IsFolder {
+					img, _ = newImageFromBytes(p.M.PhotoId)
+				}
+				break
+			}
+		case api.CollectionLiveTvChannels, api.CollectionLiveTvGuide, api.CollectionLiveTvTimers,
+			api.CollectionLiveTvRecordings:
+			program := models.LiveTvTable.SelectedRows(true)
+			for _, l := range program {
+				if l.M.ItemId != "" {
+					img, _ = newImageFromBytes(l.M.ItemId)
+				}
+				ovw = l.M.Overview
+				itemId = l.M.ItemId
 				break
 			}
 		default:
@@ -135,6 +167,51 @@ func newContentPanel() (*unison.Panel, bool, bool) {
 			unison.InstallDefaultFieldBorder(textPanel, scroller)
 			panel.AddChild(scroller.AsPanel())
 		}
+		// every version of the item with all of its video, audio & subtitle streams
+		if item, ok := itemCache[itemId]; ok && len(item.MediaSources) > 0 {
+			pr = true
+			_, scroller := newListTable(models.StreamTableDescription, api.GetMediaSourceDisplayData(item))
+			scroller.SetLayoutData(&unison.FlexLayoutData{
+				SizeHint: unison.NewSize(streamPanelWidth, streamPanelHeight),
+				HSpan:    2,
+				HAlign:   align.Fill,
+				VAlign:   align.Fill,
+				HGrab:    true,
+				VGrab:    true,
+			})
+			panel.AddChild(scroller)
+		}
+		// full cast & crew, not limited like the table columns
+		if item, ok := itemCache[itemId]; ok && len(item.People) > 0 {
+			pr = true
+			if crew := crewSummary(item); crew != "" {
+				lbl := unison.NewLabel()
+				lbl.SetTitle(crew)
+				lbl.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+				panel.AddChild(lbl)
+			}
+			_, scroller := newListTable(models.CastTableDescription, api.GetCastDisplayData(item))
+			scroller.SetLayoutData(&unison.FlexLayoutData{
+				SizeHint: unison.NewSize(streamPanelWidth, castPanelHeight),
+				HSpan:    2,
+				HAlign:   align.Fill,
+				VAlign:   align.Fill,
+				HGrab:    true,
+				VGrab:    true,
+			})
+			panel.AddChild(scroller)
+		}
 	}
 	return panel, pl, pr
 }
+
+// Producers & composers have no table column, they are listed here, limited like the columns (see LimitRoles)
+func crewSummary(item api.BaseItemDto) string {
+	var parts []string
+	for _, r := range []api.PersonType{api.PRODUCER_PersonType, api.COMPOSER_PersonType} {
+		if names := api.GetPeople(item, r); names != "" {
+			parts = append(parts, roleCaptions[string(r)]+": "+names)
+		}
+	}
+	return strings.Join(parts, "   ")
+}
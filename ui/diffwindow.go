@@ -0,0 +1,199 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Snapshot diff window: items added, removed & changed in the current view between two snapshots, or a snapshot
+// and the server; also exported without a window, e.g. for a weekly digest (see ExportSnapshotDiff)
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"Emby_Explorer/snapshot"
+	"errors"
+	"github.com/richardwilkes/unison"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	diffWindowWidth  float32 = 1300
+	diffWindowHeight float32 = 600
+	diffPeriod               = 7 * 24 * time.Hour // compared to a week before by default
+)
+
+var diffWindow *unison.Window
+var diffData []models.ListData
+
+func canShowDiff() bool {
+	return len(userViews) > 0 && canSnapshot(userViews[viewsPopupMenu.SelectedIndex()])
+}
+
+func diffWindowDisplay() {
+	if diffWindow != nil {
+		diffWindow.Dispose()
+	}
+	view := userViews[viewsPopupMenu.SelectedIndex()]
+	key := snapshotKey()
+	history, _ := snapshot.History(key, view.Id)
+	online := api.EmbySession.AccessToken != ""
+	if len(history) == 0 && !online {
+		DialogToDisplayErrorMessage(assets.CapSnapshotDiff, assets.ErrNoSnapshots)
+		return
+	}
+	wnd, err := newListWindow(assets.CapSnapshotDiff+" - "+view.Name, diffWindowWidth, diffWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	diffWindow = wnd
+	diffData = nil
+	table, scrollArea := newListTable(models.DiffTableDescription, diffData)
+	toolbar, status := newListToolbar()
+	// the server is offered as the newest version, if connected
+	var versions []string
+	if online {
+		versions = append(versions, assets.CapLive)
+	}
+	for _, t := range history {
+		versions = append(versions, t.Local().Format(api.DateFormat))
+	}
+	taken := func(index int) (time.Time, bool) {
+		if online {
+			if index == 0 {
+				return time.Time{}, true
+			}
+			index--
+		}
+		return history[index], false
+	}
+	fromPopup := addListPopup(toolbar, versions...)
+	toPopup := addListPopup(toolbar, versions...)
+	from := len(versions) - len(history) + snapshotIndex(history, time.Now().Add(-diffPeriod))
+	if len(history) == 0 {
+		from = 0
+	}
+	fromPopup.SelectIndex(from)
+	var compareBtn *unison.Button
+	compare := func() {
+		before, beforeLive := taken(fromPopup.SelectedIndex())
+		after, afterLive := taken(toPopup.SelectedIndex())
+		compareBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go func() {
+			changes, err := diffVersions(key, view, before, beforeLive, after, afterLive)
+			data := api.GetDiffDisplayData(changes)
+			unison.InvokeTask(func() {
+				if diffWindow != wnd {
+					return
+				}
+				compareBtn.SetEnabled(true)
+				if err != nil {
+					status.SetTitle(assets.ErrFetchItemsFailed + " " + err.Error())
+				} else {
+					diffData = data
+					setListRows(table, diffData)
+					status.SetTitle(diffSummary(changes))
+					table.MarkForRedraw()
+				}
+				status.Parent().MarkForLayoutAndRedraw()
+			})
+		}()
+	}
+	compareBtn = addListButton(toolbar, assets.CapCompare, assets.IconFetch, compare)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.DiffTableDescription, diffData, assets.CapSnapshotDiff)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		diffWindow = nil
+	}
+	wnd.ToFront()
+	compare()
+}
+
+// Index of the newest snapshot taken at or before t, the oldest one if there is none (history is newest first)
+func snapshotIndex(history []time.Time, t time.Time) int {
+	for i, taken := range history {
+		if !taken.After(t) {
+			return i
+		}
+	}
+	return len(history) - 1
+}
+
+// Runs in the background; the live version is fetched from the server
+func diffVersions(key snapshot.Key, view api.UserView, before time.Time, beforeLive bool, after time.Time,
+	afterLive bool) ([]api.ItemChange, error) {
+	load := func(taken time.Time, live bool) ([]api.BaseItemDto, error) {
+		if live {
+			return api.UserGetItenmsInt(view.Id, view.CollectionType)
+		}
+		s, err := snapshot.Load(key, view.Id, taken)
+		return s.Items, err
+	}
+	beforeItems, err := load(before, beforeLive)
+	if err != nil {
+		return nil, err
+	}
+	afterItems, err := load(after, afterLive)
+	if err != nil {
+		return nil, err
+	}
+	return api.DiffItems(beforeItems, afterItems), nil
+}
+
+func diffSummary(changes []api.ItemChange) string {
+	added, removed, changed := api.DiffCounts(changes)
+	return assets.CapAdded + ": " + strconv.Itoa(added) + "   " + assets.CapRemoved + ": " + strconv.Itoa(removed) +
+		"   " + assets.CapChanged + ": " + strconv.Itoa(changed)
+}
+
+// ExportSnapshotDiff compares the newest snapshot of the view named to the one taken since before it, and exports
+// the differences to path; used from the command line, the server & user are those of the preferences
+func ExportSnapshotDiff(viewName string, since time.Duration, path string) (string, error) {
+	_ = LoadPreferences()
+	key := snapshotKey()
+	views, _ := snapshot.LoadViews(key)
+	var view api.UserView
+	for _, v := range views {
+		if strings.EqualFold(v.Name, viewName) {
+			view = v
+		}
+	}
+	if view.Id == "" {
+		return "", errors.New(assets.ErrUnknownView + " " + viewName)
+	}
+	history, err := snapshot.History(key, view.Id)
+	if err != nil {
+		return "", err
+	}
+	switch len(history) {
+	case 0:
+		return "", errors.New(assets.ErrNoSnapshots)
+	case 1:
+		return "", errors.New(assets.ErrOneSnapshot)
+	default:
+	}
+	after := history[0]
+	before := history[snapshotIndex(history, after.Add(-since))]
+	if before.Equal(after) {
+		return "", errors.New(assets.ErrNoEarlierSnapshot)
+	}
+	changes, err := diffVersions(key, view, before, false, after, false)
+	if err != nil {
+		return "", err
+	}
+	if err = writeList(models.DiffTableDescription, api.GetDiffDisplayData(changes), path,
+		assets.CapSnapshotDiff); err != nil {
+		return "", err
+	}
+	return view.Name + " " + before.Local().Format(api.DateFormat) + " - " + after.Local().Format(api.DateFormat) +
+		": " + diffSummary(changes), nil
+}
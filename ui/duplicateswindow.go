@@ -0,0 +1,80 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Duplicate finder window, searching the items of all video views
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"strconv"
+)
+
+const (
+	duplicatesWindowWidth  float32 = 1200
+	duplicatesWindowHeight float32 = 600
+)
+
+var duplicatesWindow *unison.Window
+var duplicatesData []models.ListData
+
+func duplicatesWindowDisplay() {
+	if duplicatesWindow != nil {
+		duplicatesWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapDuplicates, duplicatesWindowWidth, duplicatesWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	duplicatesWindow = wnd
+	duplicatesData = nil
+	table, scrollArea := newListTable(models.DuplicateTableDescription, duplicatesData)
+	toolbar, status := newListToolbar()
+	var refreshBtn *unison.Button
+	search := func() {
+		refreshBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go findDuplicates(userViews, table, status, refreshBtn)
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, search)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.DuplicateTableDescription, duplicatesData, assets.CapDuplicates)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		duplicatesWindow = nil
+	}
+	wnd.ToFront()
+	search()
+}
+
+// Runs in the background, see fetchVideoViews
+func findDuplicates(sources []api.UserView, table *unison.Table[*models.ListRow], status *unison.Label,
+	refreshBtn *unison.Button) {
+	views, failed := fetchVideoViews(sources)
+	data := api.FindDuplicates(views)
+	unison.InvokeTask(func() {
+		if duplicatesWindow == nil {
+			return
+		}
+		duplicatesData = data
+		setListRows(table, duplicatesData)
+		refreshBtn.SetEnabled(true)
+		if failed != nil {
+			status.SetTitle(assets.ErrFetchItemsFailed + " " + failed.Error())
+		} else {
+			status.SetTitle(assets.CapGroups + ": " + strconv.Itoa(len(duplicatesData)))
+		}
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}
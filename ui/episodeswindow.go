@@ -0,0 +1,101 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Episode check window for the current TV view, exportable as a checklist
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"strconv"
+)
+
+const (
+	episodesWindowWidth  float32 = 1100
+	episodesWindowHeight float32 = 600
+)
+
+var episodesWindow *unison.Window
+var episodesData []models.ListData
+
+func episodesWindowDisplay() {
+	if episodesWindow != nil {
+		episodesWindow.Dispose()
+	}
+	view := userViews[viewsPopupMenu.SelectedIndex()]
+	dto := make([]api.BaseItemDto, 0, len(itemCache))
+	for _, d := range itemCache {
+		dto = append(dto, d)
+	}
+	wnd, err := newListWindow(assets.CapEpisodes+" - "+view.Name, episodesWindowWidth, episodesWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	episodesWindow = wnd
+	episodesData = nil
+	table, scrollArea := newListTable(models.EpisodeReportTableDescription, episodesData)
+	toolbar, status := newListToolbar()
+	var refreshBtn *unison.Button
+	check := func() {
+		refreshBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go checkEpisodes(wnd, view, dto, table, status, refreshBtn)
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, check)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.EpisodeReportTableDescription, episodesData, assets.CapEpisodes)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		episodesWindow = nil
+	}
+	wnd.ToFront()
+	check()
+}
+
+// The view's items are completed by the virtual items of missing episodes, if the server provides them
+func checkEpisodes(wnd *unison.Window, view api.UserView, dto []api.BaseItemDto, table *unison.Table[*models.ListRow],
+	status *unison.Label, refreshBtn *unison.Button) {
+	items := dto
+	missing, err := api.UserGetMissingEpisodesInt(view.Id)
+	if err == nil {
+		present := make(map[string]bool)
+		for _, d := range dto {
+			present[d.Id] = true
+		}
+		for _, m := range missing {
+			if !present[m.Id] {
+				items = append(items, m)
+			}
+		}
+	}
+	data := api.GetEpisodeReport(items)
+	unison.InvokeTask(func() {
+		if episodesWindow != wnd {
+			return //closed or replaced meanwhile
+		}
+		episodesData = data
+		setListRows(table, episodesData)
+		refreshBtn.SetEnabled(true)
+		issues := 0
+		for _, d := range episodesData {
+			issues += len(d.Children)
+		}
+		title := assets.CapTVShows + ": " + strconv.Itoa(len(episodesData)) + "   " + assets.CapIssues + ": " +
+			strconv.Itoa(issues)
+		if err != nil {
+			title = assets.ErrFetchItemsFailed + " " + err.Error() + "   " + title
+		}
+		status.SetTitle(title)
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}
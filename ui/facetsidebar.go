@@ -0,0 +1,160 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Facet sidebar left of the table: genres, studios, tags, years & ratings of the current view with their counts
+// Checked values filter the table, combined with AND or OR
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"strconv"
+)
+
+const facetSidebarWidth float32 = 230
+
+var bodyPanel *unison.Panel
+var facetSidebar *unison.ScrollPanel
+var facetList *unison.Panel
+
+// Sidebar & table side by side, the sidebar is only added if switched on (see showFacetSidebar)
+func createBodyPanel() *unison.Panel {
+	bodyPanel = unison.NewPanel()
+	bodyPanel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: 5,
+	})
+	bodyPanel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	facetSidebar = createFacetSidebar()
+	bodyPanel.AddChild(createTablePanel())
+	return bodyPanel
+}
+
+func createFacetSidebar() *unison.ScrollPanel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(5)))
+	facetMatchPopup := unison.NewPopupMenu[string]()
+	facetMatchPopup.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	facetMatchPopup.SetFocusable(false)
+	facetMatchPopup.AddItem(assets.CapMatchAll, assets.CapMatchAny)
+	facetMatchPopup.SelectIndex(0)
+	facetMatchPopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		facetSelection.MatchAll = p.SelectedIndex() == 0
+		facetsChanged()
+	}
+	panel.AddChild(facetMatchPopup)
+	clearBtn := unison.NewButton()
+	clearBtn.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	clearBtn.SetTitle(assets.CapClear)
+	clearBtn.SetFocusable(false)
+	clearBtn.ClickCallback = func() {
+		facetSelection.Selected = make(map[models.FacetValue]bool)
+		updateFacetList()
+		facetsChanged()
+	}
+	panel.AddChild(clearBtn)
+	facetList = unison.NewPanel()
+	facetList.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: 2,
+	})
+	panel.AddChild(facetList)
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(panel, behavior.Fill, behavior.Unmodified)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(facetSidebarWidth, 0),
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+		VGrab:    true,
+	})
+	scroller.SetBorder(unison.NewDefaultFieldBorder(false))
+	return scroller
+}
+
+func showFacetSidebar(show bool) {
+	layout, _ := bodyPanel.Layout().(*unison.FlexLayout)
+	if show && facetSidebar.Parent() == nil {
+		bodyPanel.AddChildAtIndex(facetSidebar, 0)
+		layout.Columns = 2
+	} else if !show && facetSidebar.Parent() != nil {
+		bodyPanel.RemoveChild(facetSidebar)
+		layout.Columns = 1
+		// hidden facets must not filter
+		if facetSelection.Active() {
+			facetSelection.Selected = make(map[models.FacetValue]bool)
+			updateFacetList()
+			facetsChanged()
+		}
+	}
+	bodyPanel.MarkForLayoutAndRedraw()
+}
+
+// Recomputes the facets of the current view, selected values still present stay selected
+func updateFacets() {
+	facets = api.GetFacets(itemList)
+	present := make(map[models.FacetValue]bool)
+	for _, counts := range facets.Counts {
+		for _, c := range counts {
+			present[c.FacetValue] = true
+		}
+	}
+	for v := range facetSelection.Selected {
+		if !present[v] {
+			delete(facetSelection.Selected, v)
+		}
+	}
+	updateFacetList()
+}
+
+func updateFacetList() {
+	facetList.RemoveAllChildren()
+	for kind, counts := range facets.Counts {
+		if len(counts) == 0 {
+			continue
+		}
+		header := unison.NewLabel()
+		header.Font = unison.EmphasizedSystemFont
+		header.SetTitle(models.FacetKinds[kind])
+		facetList.AddChild(header)
+		for _, c := range counts {
+			value := c.FacetValue
+			box := unison.NewCheckBox()
+			box.SetTitle(value.Value + " (" + strconv.Itoa(c.Count) + ")")
+			box.State = checkState(facetSelection.Selected[value])
+			box.SetFocusable(false)
+			box.ClickCallback = func() {
+				if facetSelection.Selected[value] {
+					delete(facetSelection.Selected, value)
+				} else {
+					facetSelection.Selected[value] = true
+				}
+				facetsChanged()
+			}
+			facetList.AddChild(box)
+		}
+	}
+	facetSidebar.MarkForLayoutAndRedraw()
+}
+
+func facetsChanged() {
+	if tableScrollArea != nil && tableScrollArea.Parent() != nil {
+		applyFilters()
+		redrawTable()
+	}
+}
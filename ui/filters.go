@@ -0,0 +1,218 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Row filters, applied to the tables and to the export
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"sort"
+	"strings"
+)
+
+var watchFilter = models.WatchAll
+var languageFilter models.LanguageFilter
+
+var qualityFilter models.QualityFilter
+
+// Facets of the current view and the values selected in the sidebar
+var facets models.Facets
+var facetSelection = models.FacetSelection{Selected: make(map[models.FacetValue]bool), MatchAll: true}
+
+// Choices of the language popup, index 0 is "no filter"
+var languageFilters []models.LanguageFilter
+
+// Choices of the quality popup, index 0 is "no filter"
+var qualityFilters = []models.QualityFilter{
+	{},
+	{ResolutionClass: models.ResolutionClasses[3]},
+	{ResolutionClass: models.ResolutionClasses[2]},
+	{ResolutionClass: models.ResolutionClasses[1]},
+	{ResolutionClass: models.ResolutionClasses[0]},
+	{AnyHDR: true},
+	{DynamicRange: models.RangeDolbyVision},
+	{DynamicRange: models.RangeHDR10Plus},
+	{DynamicRange: models.RangeHDR10},
+	{DynamicRange: models.RangeHLG},
+	{DynamicRange: models.RangeSDR},
+}
+
+func filterActive() bool {
+	return watchFilter != models.WatchAll || languageFilter.Active() || qualityFilter.Active() || facetSelection.Active()
+}
+
+func facetAccepted(itemId string) bool {
+	return facetSelection.Matches(facets.Items[itemId])
+}
+
+func mediaAccepted(m models.MediaInfo) bool {
+	return m.Matches(languageFilter) && m.MatchesQuality(qualityFilter)
+}
+
+func movieAccepted(m models.MovieData) bool {
+	return m.UserData.Matches(watchFilter) && mediaAccepted(m.MediaInfo) && facetAccepted(m.MovieId)
+}
+
+// Series & season rows are only shown unfiltered, episodes carry the series and season names anyway
+func tvShowAccepted(t models.TVShowData) bool {
+	if !filterActive() {
+		return true
+	}
+	return t.Type_ == api.EpisodeType && t.UserData.Matches(watchFilter) && mediaAccepted(t.MediaInfo) &&
+		facetAccepted(t.EpisodeId)
+}
+
+func homeVideoAccepted(h models.HomeVideoData) bool {
+	return h.UserData.Matches(watchFilter) && mediaAccepted(h.MediaInfo) && facetAccepted(h.VideoId)
+}
+
+func photoAccepted(p models.PhotoData) bool {
+	if !filterActive() {
+		return true
+	}
+	return !p.IsFolder && p.UserData.Matches(watchFilter) && facetAccepted(p.PhotoId)
+}
+
+func liveTvAccepted(l models.LiveTvData) bool {
+	id := l.ItemId
+	if collectionType == api.CollectionLiveTvChannels {
+		id = l.ChannelId
+	}
+	return l.UserData.Matches(watchFilter) && facetAccepted(id)
+}
+
+// unison's filter callback returns true for rows to be hidden
+func applyFilters() {
+	switch collectionType {
+	case api.CollectionMovies:
+		if filterActive() {
+			models.MovieTable.ApplyFilter(func(row *models.MovieRow) bool { return !movieAccepted(row.M) })
+		} else {
+			models.MovieTable.ApplyFilter(nil)
+		}
+	case api.CollectionTVShows:
+		if filterActive() {
+			models.TVShowTable.ApplyFilter(func(row *models.TVShowRow) bool { return !tvShowAccepted(row.M) })
+		} else {
+			models.TVShowTable.ApplyFilter(nil)
+		}
+	case api.CollectionHomeVideos:
+		if filterActive() {
+			models.HomeVideoTable.ApplyFilter(func(row *models.HomeVideoRow) bool { return !homeVideoAccepted(row.M) })
+		} else {
+			models.HomeVideoTable.ApplyFilter(nil)
+		}
+	case api.CollectionPhotos:
+		if photoGridMode {
+			return
+		}
+		if filterActive() {
+			models.PhotoTable.ApplyFilter(func(row *models.PhotoRow) bool { return !photoAccepted(row.M) })
+		} else {
+			models.PhotoTable.ApplyFilter(nil)
+		}
+	case api.CollectionLiveTvChannels, api.CollectionLiveTvGuide, api.CollectionLiveTvTimers,
+		api.CollectionLiveTvRecordings:
+		if filterActive() {
+			models.LiveTvTable.ApplyFilter(func(row *models.LiveTvRow) bool { return !liveTvAccepted(row.M) })
+		} else {
+			models.LiveTvTable.ApplyFilter(nil)
+		}
+	default:
+	}
+}
+
+// Offers "missing audio/subtitle language" for every language found in the current view, keeping the current choice
+func updateLanguageFilters() {
+	var infos []models.MediaInfo
+	switch collectionType {
+	case api.CollectionMovies:
+		for _, m := range models.MovieDataTable {
+			infos = append(infos, m.MediaInfo)
+		}
+	case api.CollectionTVShows:
+		for _, t := range models.TVShowDataTable {
+			infos = append(infos, t.MediaInfo)
+		}
+	case api.CollectionHomeVideos:
+		for _, h := range models.HomeVideoDataTable {
+			infos = append(infos, h.MediaInfo)
+		}
+	default:
+	}
+	audio, subtitles := make(map[string]bool), make(map[string]bool)
+	for _, i := range infos {
+		for _, l := range i.AudioLanguages {
+			audio[strings.ToLower(l)] = true
+		}
+		for _, l := range i.SubtitleLanguages {
+			subtitles[strings.ToLower(l)] = true
+		}
+	}
+	languageFilters = []models.LanguageFilter{{}}
+	for _, l := range sortedKeys(audio) {
+		languageFilters = append(languageFilters, models.LanguageFilter{Language: l})
+	}
+	for _, l := range sortedKeys(subtitles) {
+		languageFilters = append(languageFilters, models.LanguageFilter{Subtitles: true, Language: l})
+	}
+	selected := 0
+	languagePopupMenu.RemoveAllItems()
+	for i, f := range languageFilters {
+		languagePopupMenu.AddItem(languageFilterCaption(f))
+		if f == languageFilter {
+			selected = i
+		}
+	}
+	languageFilter = languageFilters[selected]
+	languagePopupMenu.SelectIndex(selected)
+	languagePopupMenu.SetEnabled(len(languageFilters) > 1)
+}
+
+// Quality only applies to video views
+func updateQualityFilter() {
+	switch collectionType {
+	case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos:
+		qualityPopupMenu.SetEnabled(true)
+	default:
+		qualityFilter = qualityFilters[0]
+		qualityPopupMenu.SelectIndex(0)
+		qualityPopupMenu.SetEnabled(false)
+	}
+}
+
+func qualityFilterCaption(f models.QualityFilter) string {
+	switch {
+	case !f.Active():
+		return assets.CapFilterAll
+	case f.AnyHDR:
+		return assets.CapFilterAnyHDR
+	case f.ResolutionClass != "":
+		return f.ResolutionClass
+	default:
+		return f.DynamicRange
+	}
+}
+
+func languageFilterCaption(f models.LanguageFilter) string {
+	switch {
+	case !f.Active():
+		return assets.CapFilterNone
+	case f.Subtitles:
+		return assets.CapSubtitles + " " + f.Language
+	default:
+		return assets.CapAudio + " " + f.Language
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
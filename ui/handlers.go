@@ -15,71 +15,305 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	time2 "time"
 )
 
 var userViews []api.UserView
 
+// Items of the current view as delivered by the server, by item id and in server order
+var itemCache = make(map[string]api.BaseItemDto)
+var itemList []api.BaseItemDto
+
+func cacheItems(dto []api.BaseItemDto) {
+	itemCache = make(map[string]api.BaseItemDto)
+	itemList = dto
+	for _, d := range dto {
+		itemCache[d.Id] = d
+	}
+}
+
+// Replaces an item of the current view after it was changed on the server, false if not part of the view
+func updateCachedItem(d api.BaseItemDto) bool {
+	if _, ok := itemCache[d.Id]; !ok {
+		return false
+	}
+	itemCache[d.Id] = d
+	for i := range itemList {
+		if itemList[i].Id == d.Id {
+			itemList[i] = d
+			break
+		}
+	}
+	return true
+}
+
+// Rebuilds the data of the current view after items were updated; the rows of the table shown take over the new data,
+// so that scroll position & selection are kept
+func updateTableRows() {
+	setDisplayData(collectionType, itemList)
+	if tableScrollArea == nil || tableScrollArea.Parent() == nil {
+		return
+	}
+	switch collectionType {
+	case api.CollectionMovies:
+		updateRows(models.MovieTable.RootRows(), models.MovieDataTable,
+			func(r *models.MovieRow) *models.MovieData { return &r.M },
+			func(d *models.MovieData) string { return d.MovieId })
+	case api.CollectionTVShows:
+		updateRows(models.TVShowTable.RootRows(), models.TVShowDataTable,
+			func(r *models.TVShowRow) *models.TVShowData { return &r.M }, tvShowItemId)
+	case api.CollectionHomeVideos:
+		updateRows(models.HomeVideoTable.RootRows(), models.HomeVideoDataTable,
+			func(r *models.HomeVideoRow) *models.HomeVideoData { return &r.M },
+			func(d *models.HomeVideoData) string {
+				if d.FolderId != "" {
+					return d.FolderId
+				}
+				return d.VideoId
+			})
+	case api.CollectionPhotos:
+		if photoGridMode {
+			return
+		}
+		updateRows(models.PhotoTable.RootRows(), models.PhotoDataTable,
+			func(r *models.PhotoRow) *models.PhotoData { return &r.M },
+			func(d *models.PhotoData) string {
+				if d.IsFolder {
+					return d.FolderId
+				}
+				return d.PhotoId
+			})
+	default:
+	}
+	redrawTable()
+}
+
+func updateRows[R unison.TableRowConstraint[R], D any](rows []R, table []D, rowData func(R) *D, id func(*D) string) {
+	index := make(map[string]int, len(table))
+	for i := range table {
+		index[id(&table[i])] = i
+	}
+	var update func(rows []R)
+	update = func(rows []R) {
+		for _, r := range rows {
+			d := rowData(r)
+			if i, ok := index[id(d)]; ok {
+				*d = table[i]
+			}
+			update(r.Children())
+		}
+	}
+	update(rows)
+}
+
+// Fetches the items of all movie, TV & home video views, each view only once (to be run in the background)
+// The last error is returned, the views fetched successfully are returned anyway
+func fetchVideoViews(sources []api.UserView) ([]api.ViewItems, error) {
+	var views []api.ViewItems
+	var failed error
+	fetched := make(map[string]bool)
+	for _, v := range sources {
+		switch v.CollectionType {
+		case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos:
+		default:
+			continue
+		}
+		if fetched[v.Id] {
+			continue
+		}
+		fetched[v.Id] = true
+		dto, err := api.UserGetItenmsInt(v.Id, v.CollectionType)
+		if err != nil {
+			failed = err
+			continue
+		}
+		views = append(views, api.ViewItems{View: v.Name, Items: dto})
+	}
+	return views, failed
+}
+
+// The views cached stay available if the server can't be reached
 func embyAuthenticateUser() {
-	userViews = nil
 	err := api.AuthenticateUserInt()
 	if err != nil {
 		DialogToDisplaySystemError(assets.ErrAuthFailed, err)
 		return
 	} else {
-		userViews, err = api.UserGetViewsInt()
+		views, err := api.UserGetViewsInt()
 		if err != nil {
 			DialogToDisplaySystemError(assets.ErrFetchViewsFailed, err)
 			return
 		}
-		viewsPopupMenu.RemoveAllItems()
-		for i, v := range userViews {
-			viewsPopupMenu.AddItem(v.Name)
-			if i == 0 {
-				viewsPopupMenu.SelectIndex(i)
-				setFunctions(false, false, true, false, false)
-			}
+		views = expandLiveTvViews(views)
+		go fetchServerInfo()
+		go saveViews(views)
+		setViews(views)
+		if len(userViews) > 0 {
+			setFunctions(false, false, true, false, false)
 		}
 	}
 }
 
-func embyFetchItemsForUser() {
+// Unless full, only the items changed since the latest snapshot of the view are fetched (see syncItems)
+func embyFetchItemsForUser(full bool) {
 	detailsBtn.SetEnabled(false)
 	exportBtn.SetEnabled(false)
+	gridBtn.SetEnabled(false)
 	index := viewsPopupMenu.SelectedIndex()
 	view := userViews[index]
-	dto, err := api.UserGetItenmsInt(view.Id, view.CollectionType)
+	if strings.HasPrefix(view.CollectionType, api.CollectionLiveTv) {
+		embyFetchLiveTv(view)
+		return
+	}
+	result, err := syncItems(view, full)
 	if err != nil {
 		DialogToDisplaySystemError(assets.ErrFetchItemsFailed, err)
 		return
 	}
-	mainContent.RemoveAllChildren()
-	switch view.CollectionType {
+	cacheItems(result.items)
+	models.ChangedItems = result.changed
+	saveSnapshot(view, result)
+	if view.CollectionType == api.CollectionPhotos {
+		photoGridMode = false
+	}
+	setDisplayData(view.CollectionType, result.items)
+	showTable()
+	setSnapshotTitle(time2.Time{})
+}
+
+// Converts the items fetched into the data table of the collection
+func setDisplayData(collection string, dto []api.BaseItemDto) {
+	switch collection {
 	case api.CollectionMovies:
 		models.MovieDataTable = api.GetMovieDisplayData(dto)
+	case api.CollectionTVShows:
+		models.TVShowDataTable = api.GetTVShowDisplayData(dto)
+	case api.CollectionHomeVideos:
+		models.HomeVideoDataTable = api.GetHomeVideoDisplayData(dto)
+	case api.CollectionPhotos:
+		models.PhotoDataTable = api.GetPhotoDisplayData(dto)
+	default:
+	}
+}
+
+// (Re-)builds the table for the current view from the data already fetched, e.g. after columns were toggled
+func showTable() {
+	if mainContent == nil || collectionType == "" {
+		return
+	}
+	stopThumbnails()
+	mainContent.RemoveAllChildren()
+	detailsBtn.SetEnabled(false)
+	exportBtn.SetEnabled(false)
+	gridBtn.SetEnabled(false)
+	updateLanguageFilters()
+	updateQualityFilter()
+	updateFacets()
+	switch collectionType {
+	case api.CollectionMovies:
 		newMovieTable(mainContent, models.MovieDataTable)
+		applyFilters()
 		if len(models.MovieDataTable) > 0 {
 			models.MovieTable.SelectByIndex(0)
 			detailsBtn.SetEnabled(true)
 			exportBtn.SetEnabled(true)
 		}
 	case api.CollectionTVShows:
-		models.TVShowDataTable = api.GetTVShowDisplayData(dto)
 		newTVShowTable(mainContent, models.TVShowDataTable)
+		applyFilters()
 		if len(models.TVShowDataTable) > 0 {
 			models.TVShowTable.SelectByIndex(0)
 			detailsBtn.SetEnabled(true)
 			exportBtn.SetEnabled(true)
 		}
 	case api.CollectionHomeVideos:
-		models.HomeVideoDataTable = api.GetHomeVideoDisplayData(dto)
 		newHomeVideoTable(mainContent, models.HomeVideoDataTable)
+		applyFilters()
 		if len(models.HomeVideoDataTable) > 0 {
 			models.HomeVideoTable.SelectByIndex(0)
+			detailsBtn.SetEnabled(true)
+			exportBtn.SetEnabled(true)
+		}
+	case api.CollectionPhotos:
+		if photoGridMode {
+			newPhotoGrid(mainContent, models.PhotoDataTable)
+		} else {
+			newPhotoTable(mainContent, models.PhotoDataTable)
+			applyFilters()
+		}
+		if len(models.PhotoDataTable) > 0 {
+			if !photoGridMode {
+				models.PhotoTable.SelectByIndex(0)
+				detailsBtn.SetEnabled(true)
+			}
+			exportBtn.SetEnabled(true)
+			gridBtn.SetEnabled(true)
+		}
+	case api.CollectionLiveTvChannels, api.CollectionLiveTvGuide, api.CollectionLiveTvTimers,
+		api.CollectionLiveTvRecordings:
+		newLiveTvTable(mainContent, models.LiveTvDataTable)
+		applyFilters()
+		if len(models.LiveTvDataTable) > 0 {
+			models.LiveTvTable.SelectByIndex(0)
+			detailsBtn.SetEnabled(true)
 			exportBtn.SetEnabled(true)
 		}
 	default:
+		setLogoPanel()
 	}
+	mainContent.MarkForLayoutAndRedraw()
+}
+
+func embyFetchLiveTv(view api.UserView) {
+	var dto []api.BaseItemDto
+	var timers []api.TimerInfoDto
+	var err error
+	switch view.CollectionType {
+	case api.CollectionLiveTvChannels:
+		dto, err = api.LiveTvGetChannelsInt()
+	case api.CollectionLiveTvGuide:
+		dto, err = api.LiveTvGetGuideInt()
+	case api.CollectionLiveTvTimers:
+		timers, err = api.LiveTvGetTimersInt()
+	case api.CollectionLiveTvRecordings:
+		dto, err = api.LiveTvGetRecordingsInt()
+	default:
+		return
+	}
+	// the table shown is kept if the fetch failed
+	if err != nil {
+		DialogToDisplaySystemError(assets.ErrFetchItemsFailed, err)
+		return
+	}
+	switch view.CollectionType {
+	case api.CollectionLiveTvChannels:
+		models.LiveTvDataTable = api.GetLiveTvChannelDisplayData(dto)
+	case api.CollectionLiveTvGuide:
+		models.LiveTvDataTable = api.GetLiveTvGuideDisplayData(dto)
+	case api.CollectionLiveTvTimers:
+		models.LiveTvDataTable = api.GetLiveTvTimerDisplayData(timers)
+	default:
+		models.LiveTvDataTable = api.GetLiveTvRecordingDisplayData(dto)
+	}
+	cacheItems(dto)
+	showTable()
+}
+
+// Live TV has no items of its own, it is split into one pseudo view per browser
+func expandLiveTvViews(views []api.UserView) []api.UserView {
+	var captions = []string{assets.CapChannels, assets.CapGuide, assets.CapTimers, assets.CapRecordings}
+	result := make([]api.UserView, 0)
+	for _, v := range views {
+		if v.CollectionType != api.CollectionLiveTv {
+			result = append(result, v)
+			continue
+		}
+		for i, t := range api.LiveTvCollectionTypes {
+			result = append(result, api.UserView{Name: v.Name + " - " + captions[i], CollectionType: t, Id: v.Id})
+		}
+	}
+	return result
 }
 
 func embyFetchDetails() {
@@ -94,85 +328,153 @@ func embyExport() {
 }
 
 func buildAndExport(collection string) {
-	var i, j int
 	var sheet string
-	var exp = make([]export.Payload, 0)
-	var hdr = make([]export.HeaderData, 0)
-	var e export.Payload
-	var c export.HeaderData
-	j = 1 // xlsx start row
+	var exp []export.Payload
+	var hdr []export.HeaderData
 	switch collection {
 	case api.CollectionMovies:
-		for i = 0; i < models.MovieTableDescription.NoOfColumns; i++ {
-			c.XLSCell = models.MovieTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-			c.Name = models.MovieTableDescription.Columns[i].Caption
-			c.Column = models.MovieTableDescription.Columns[i].XLSColumn
-			c.Width = models.MovieTableDescription.Columns[i].XLSColumnWidth
-			hdr = append(hdr, c)
-		}
-		for _, m := range models.MovieDataTable {
-			j++
-			for i = 0; i < models.MovieTableDescription.NoOfColumns; i++ {
-				e.XLSCell = models.MovieTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-				e.Data = models.GetMovieDataField(i, m)
-				exp = append(exp, e)
-			}
-		}
+		hdr, exp = buildExportData(tableColumns(models.MovieTableDescription), len(models.MovieDataTable), func(row int) bool {
+			return movieAccepted(models.MovieDataTable[row])
+		}, func(row, col int) string {
+			return models.GetMovieDataField(col, models.MovieDataTable[row])
+		})
 		sheet = assets.CapMovies
 	case api.CollectionTVShows:
-		for i = 0; i < models.TVShowTableDescription.NoOfColumns; i++ {
-			c.XLSCell = models.TVShowTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-			c.Name = models.TVShowTableDescription.Columns[i].Caption
-			c.Column = models.TVShowTableDescription.Columns[i].XLSColumn
-			c.Width = models.TVShowTableDescription.Columns[i].XLSColumnWidth
-			hdr = append(hdr, c)
-		}
-		for _, t := range models.TVShowDataTable {
-			j++
-			for i = 0; i < models.TVShowTableDescription.NoOfColumns; i++ {
-				e.XLSCell = models.TVShowTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-				e.Data = models.GetTVShowDataField(i, t)
-				exp = append(exp, e)
-			}
-		}
+		hdr, exp = buildExportData(tableColumns(models.TVShowTableDescription), len(models.TVShowDataTable), func(row int) bool {
+			return tvShowAccepted(models.TVShowDataTable[row])
+		}, func(row, col int) string {
+			return models.GetTVShowDataField(col, models.TVShowDataTable[row])
+		})
 		sheet = assets.CapTVShows
 	case api.CollectionHomeVideos:
-		for i = 0; i < models.HomeVideoTableDescription.NoOfColumns; i++ {
-			c.XLSCell = models.HomeVideoTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-			c.Name = models.HomeVideoTableDescription.Columns[i].Caption
-			c.Column = models.HomeVideoTableDescription.Columns[i].XLSColumn
-			c.Width = models.HomeVideoTableDescription.Columns[i].XLSColumnWidth
-			hdr = append(hdr, c)
-		}
-		for _, h := range models.HomeVideoDataTable {
-			j++
-			for i = 0; i < models.HomeVideoTableDescription.NoOfColumns; i++ {
-				e.XLSCell = models.HomeVideoTableDescription.Columns[i].XLSColumn + strconv.Itoa(j)
-				e.Data = models.GetHomeVideoDataField(i, h)
-				exp = append(exp, e)
-			}
-		}
+		hdr, exp = buildExportData(tableColumns(models.HomeVideoTableDescription), len(models.HomeVideoDataTable), func(row int) bool {
+			return homeVideoAccepted(models.HomeVideoDataTable[row])
+		}, func(row, col int) string {
+			return models.GetHomeVideoDataField(col, models.HomeVideoDataTable[row])
+		})
 		sheet = assets.CapHomeVideos
+	case api.CollectionPhotos:
+		hdr, exp = buildExportData(tableColumns(models.PhotoTableDescription), len(models.PhotoDataTable), func(row int) bool {
+			return !models.PhotoDataTable[row].IsFolder && photoAccepted(models.PhotoDataTable[row])
+		}, func(row, col int) string {
+			return models.GetPhotoDataField(col, models.PhotoDataTable[row])
+		})
+		sheet = assets.CapPhotos
+	case api.CollectionLiveTvChannels, api.CollectionLiveTvGuide, api.CollectionLiveTvTimers, api.CollectionLiveTvRecordings:
+		hdr, exp = buildExportData(tableColumns(models.LiveTvTableDescription), len(models.LiveTvDataTable), func(row int) bool {
+			return liveTvAccepted(models.LiveTvDataTable[row])
+		}, func(row, col int) string {
+			return models.GetLiveTvDataField(col, models.LiveTvDataTable[row])
+		})
+		sheet = liveTvSheetName(collection)
 	default:
 		return
 	}
+	extensions := []string{assets.FileExtension}
+	if collection == api.CollectionPhotos {
+		// geotagged photos may also be exported as GPX or KML, depending on the chosen file extension
+		extensions = append(extensions, assets.GpxFileExtension, assets.KmlFileExtension)
+	}
+	if p, ok := runExportDialog(sheet, extensions...); ok {
+		var err error
+		switch strings.ToLower(strings.TrimPrefix(path.Ext(p), ".")) {
+		case assets.GpxFileExtension:
+			err = export.GpxExport(buildWaypoints(), p, assets.AppName)
+		case assets.KmlFileExtension:
+			err = export.KmlExport(buildWaypoints(), p, assets.CapEmby+" "+sheet)
+		default:
+			sheets := []export.Sheet{{Name: sheet, Header: hdr, Data: exp}}
+			if stats, ok := statisticsSheet(collection); ok {
+				sheets = append(sheets, stats)
+			}
+			err = export.XlsxExportSheets(sheets, p)
+		}
+		if err != nil {
+			DialogToDisplaySystemError(assets.CapError, err)
+		}
+	}
+}
+
+// Save dialog, proposing a file name built from sheet name & date in the last export folder
+func runExportDialog(sheet string, extensions ...string) (string, bool) {
 	date := time2.Now().Format("2006-01-02")
 	folder := settings.GetLastExportFolder()
 	if folder == "" {
 		folder, _ = os.UserHomeDir()
 	}
-	preferredFileName := assets.CapEmby + " " + sheet + " " + date + "." + assets.FileExtension
+	preferredFileName := assets.CapEmby + " " + sheet + " " + date + "." + extensions[0]
 	dialog := unison.NewSaveDialog()
 	dialog.SetInitialFileName(preferredFileName)
 	dialog.SetInitialDirectory(folder)
-	dialog.SetAllowedExtensions(assets.FileExtension)
+	dialog.SetAllowedExtensions(extensions...)
 	if dialog.RunModal() == true {
 		p := dialog.Path()
 		lastFolder, _ := path.Split(p)
 		settings.SetLastExportFolder(lastFolder)
-		err := export.XlsxExport(exp, hdr, p, sheet)
-		if err != nil {
-			DialogToDisplaySystemError(assets.CapError, err)
+		return p, true
+	}
+	return "", false
+}
+
+// Header & cells for all rows passing the current filter, using the columns given
+func buildExportData(columns []models.ColumnDescription, count int, accepted func(row int) bool,
+	field func(row, col int) string) ([]export.HeaderData, []export.Payload) {
+	var exp = make([]export.Payload, 0)
+	var hdr = make([]export.HeaderData, 0)
+	var e export.Payload
+	var c export.HeaderData
+	j := 1 // xlsx start row
+	for _, col := range columns {
+		c.XLSCell = col.XLSColumn + strconv.Itoa(j)
+		c.Name = col.Caption
+		c.Column = col.XLSColumn
+		c.Width = col.XLSColumnWidth
+		hdr = append(hdr, c)
+	}
+	for row := 0; row < count; row++ {
+		if !accepted(row) {
+			continue
+		}
+		j++
+		for i, col := range columns {
+			e.XLSCell = col.XLSColumn + strconv.Itoa(j)
+			e.Data = field(row, i)
+			exp = append(exp, e)
+		}
+	}
+	return hdr, exp
+}
+
+func liveTvSheetName(collection string) string {
+	var s = assets.CapLiveTv
+	switch collection {
+	case api.CollectionLiveTvChannels:
+		s = s + " " + assets.CapChannels
+	case api.CollectionLiveTvGuide:
+		s = s + " " + assets.CapGuide
+	case api.CollectionLiveTvTimers:
+		s = s + " " + assets.CapTimers
+	case api.CollectionLiveTvRecordings:
+		s = s + " " + assets.CapRecordings
+	default:
+	}
+	return s
+}
+
+func buildWaypoints() []export.Waypoint {
+	var points = make([]export.Waypoint, 0)
+	for _, p := range models.PhotoDataTable {
+		if p.IsFolder || p.Latitude == "" || p.Longitude == "" || !photoAccepted(p) {
+			continue
 		}
+		var w export.Waypoint
+		w.Name = p.Name
+		w.Description = p.Path
+		w.Latitude, _ = strconv.ParseFloat(p.Latitude, 64)
+		w.Longitude, _ = strconv.ParseFloat(p.Longitude, 64)
+		w.Altitude, _ = strconv.ParseFloat(p.Altitude, 64)
+		w.Time = p.Taken
+		points = append(points, w)
 	}
+	return points
 }
@@ -0,0 +1,150 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Health check window: issues found in the current view, filtered by severity & rule
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"Emby_Explorer/settings"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
+	"strconv"
+)
+
+const (
+	healthWindowWidth  float32 = 1100
+	healthWindowHeight float32 = 600
+)
+
+var healthWindow *unison.Window
+
+func healthWindowDisplay() {
+	if healthWindow != nil {
+		healthWindow.Dispose()
+	}
+	view := userViews[viewsPopupMenu.SelectedIndex()]
+	dto := make([]api.BaseItemDto, 0, len(itemCache))
+	for _, d := range itemCache {
+		dto = append(dto, d)
+	}
+	wnd, err := newListWindow(assets.CapHealth+" - "+view.Name, healthWindowWidth, healthWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	healthWindow = wnd
+	rules := api.HealthRules()
+	var issues []models.HealthIssue
+	var data []models.ListData
+	var filter models.HealthFilter
+	table, scrollArea := newListTable(models.HealthTableDescription, data)
+	toolbar, status := newListToolbar()
+	// severity popup: all, then most severe first
+	severityPopup := addListPopup(toolbar, assets.CapSeverity+": "+assets.CapFilterAll,
+		models.SeverityError.String(), models.SeverityWarning.String(), models.SeverityInfo.String())
+	ruleNames := []string{assets.CapAllRules}
+	for _, r := range rules {
+		ruleNames = append(ruleNames, r.Name)
+	}
+	rulePopup := addListPopup(toolbar, ruleNames...)
+	update := func() {
+		data = models.HealthListData(issues, filter)
+		setListRows(table, data)
+		status.SetTitle(assets.CapIssues + ": " + strconv.Itoa(len(data)) + " / " + strconv.Itoa(len(issues)))
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	}
+	runChecks := func() {
+		issues = api.CheckHealth(dto, disabledRules())
+		update()
+	}
+	severityPopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		filter.Severity = nil
+		if i := p.SelectedIndex(); i > 0 {
+			s := models.SeverityError - models.Severity(i-1)
+			filter.Severity = &s
+		}
+		update()
+	}
+	rulePopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		filter.RuleId = ""
+		if i := p.SelectedIndex(); i > 0 {
+			filter.RuleId = rules[i-1].Id
+		}
+		update()
+	}
+	addListButton(toolbar, assets.CapRules, assets.IconPreferences, func() {
+		if healthRulesDialog(rules) {
+			runChecks()
+		}
+	})
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.HealthTableDescription, data, assets.CapHealth)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		healthWindow = nil
+	}
+	runChecks()
+	wnd.ToFront()
+}
+
+func disabledRules() map[string]bool {
+	result := make(map[string]bool)
+	for _, id := range settings.GetDisabledRules() {
+		result[id] = true
+	}
+	return result
+}
+
+// Enables & disables rules, returns true if confirmed
+func healthRulesDialog(rules []models.HealthRule) bool {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 4,
+		VSpacing: unison.StdVSpacing,
+	})
+	disabled := disabledRules()
+	boxes := make([]*unison.CheckBox, 0, len(rules))
+	for _, r := range rules {
+		box := unison.NewCheckBox()
+		box.SetTitle(r.Name)
+		box.State = checkState(!disabled[r.Id])
+		boxes = append(boxes, box)
+		panel.AddChild(box)
+		lbl := unison.NewLabel()
+		lbl.Font = unison.LabelFont
+		lbl.SetTitle(r.Severity.String())
+		lbl.SetLayoutData(align.Middle)
+		panel.AddChild(lbl)
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo(), unison.NewCancelButtonInfo()},
+		unison.NotResizableWindowOption())
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return false
+	}
+	dialog.Window().SetTitle(assets.CapRules)
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return false
+	}
+	ids := make([]string, 0)
+	for i, r := range rules {
+		if boxes[i].State != check.On {
+			ids = append(ids, r.Id)
+		}
+	}
+	settings.SetDisabledRules(ids)
+	return true
+}
@@ -0,0 +1,92 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Dialog for the number of names per role shown in the table columns (actors, directors, ...)
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/settings"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"strconv"
+)
+
+const maxPeopleLimit = 99
+
+var roleCaptions = map[string]string{
+	string(api.ACTOR_PersonType):     assets.CapActors,
+	string(api.DIRECTOR_PersonType):  assets.CapDirectors,
+	string(api.WRITER_PersonType):    assets.CapWriters,
+	string(api.GUESTSTAR_PersonType): assets.CapGuestStars,
+	string(api.PRODUCER_PersonType):  assets.CapProducers,
+	string(api.COMPOSER_PersonType):  assets.CapComposers,
+	api.StudioRole:                   assets.CapStudios,
+}
+
+func peopleLimitsDialog() {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	fields := make([]*unison.Field, 0, len(api.LimitRoles))
+	for _, role := range api.LimitRoles {
+		lbl := unison.NewLabel()
+		lbl.Font = unison.LabelFont
+		lbl.SetTitle(roleCaptions[role])
+		lbl.SetLayoutData(align.Middle)
+		panel.AddChild(lbl)
+		fld := unison.NewField()
+		fld.Font = unison.FieldFont
+		fld.MinimumTextWidth = inpTextSizeMin
+		fld.SetText(strconv.Itoa(api.GetPeopleLimit(role)))
+		fields = append(fields, fld)
+		panel.AddChild(fld)
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo(), unison.NewCancelButtonInfo()},
+		unison.NotResizableWindowOption())
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	dialog.Window().SetTitle(assets.CapLimits)
+	ok := dialog.Button(unison.ModalResponseOK)
+	for _, fld := range fields {
+		fld.ModifiedCallback = func(_, _ *unison.FieldState) {
+			valid := true
+			for _, f := range fields {
+				_, valid = parseLimit(f.Text())
+				if !valid {
+					break
+				}
+			}
+			ok.SetEnabled(valid)
+		}
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	limits := make(map[string]int)
+	for i, role := range api.LimitRoles {
+		limits[role], _ = parseLimit(fields[i].Text())
+	}
+	settings.SetPeopleLimits(limits)
+	api.SetPeopleLimits(limits)
+	// the columns are filled when the items are converted, so convert them again
+	if tableScrollArea != nil && tableScrollArea.Parent() != nil {
+		setDisplayData(collectionType, itemList)
+		showTable()
+	}
+}
+
+func parseLimit(text string) (int, bool) {
+	n, err := strconv.Atoi(text)
+	return n, err == nil && n >= 0 && n <= maxPeopleLimit
+}
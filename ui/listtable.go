@@ -0,0 +1,171 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Tables for report & monitor windows, based on the generic list model
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+func newListTable(desc models.TableDescription, data []models.ListData) (*unison.Table[*models.ListRow], *unison.ScrollPanel) {
+	table := unison.NewTable[*models.ListRow](&unison.SimpleTableModel[*models.ListRow]{})
+	columns := desc.Columns[:desc.NoOfColumns]
+	table.Columns = newColumnInfo(len(columns))
+	setListRows(table, data)
+	table.SizeColumnsToFit(true)
+	header := unison.NewTableHeader[*models.ListRow](table, newColumnHeaders[*models.ListRow](columns)...)
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+	})
+	scrollArea := unison.NewScrollPanel()
+	scrollArea.SetContent(table, behavior.Fill, behavior.Fill)
+	scrollArea.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	scrollArea.SetColumnHeader(header)
+	return table, scrollArea
+}
+
+// Replaces all rows, rows selected before stay selected as long as their key is still present
+func setListRows(table *unison.Table[*models.ListRow], data []models.ListData) {
+	selected := make(map[string]bool)
+	for _, r := range table.SelectedRows(false) {
+		if r.M.Key != "" {
+			selected[r.M.Key] = true
+		}
+	}
+	rows := make([]*models.ListRow, 0)
+	indexes := make([]int, 0)
+	for i, m := range data {
+		rows = append(rows, models.NewListRow(tid.MustNewTID('a'), table, m))
+		if selected[m.Key] {
+			indexes = append(indexes, i)
+		}
+	}
+	table.SetRootRows(rows)
+	if len(indexes) > 0 {
+		table.SelectByIndex(indexes...)
+	}
+}
+
+// Children are exported right after their parent
+func flattenList(data []models.ListData) []models.ListData {
+	result := make([]models.ListData, 0)
+	for _, d := range data {
+		result = append(result, d)
+		result = append(result, flattenList(d.Children)...)
+	}
+	return result
+}
+
+func exportList(desc models.TableDescription, data []models.ListData, sheet string) {
+	if p, ok := runExportDialog(sheet, assets.FileExtension); ok {
+		if err := writeList(desc, data, p, sheet); err != nil {
+			DialogToDisplaySystemError(assets.CapError, err)
+		}
+	}
+}
+
+// Children are exported right after their parent
+func writeList(desc models.TableDescription, data []models.ListData, path string, sheet string) error {
+	rows := flattenList(data)
+	hdr, exp := buildExportData(desc.Columns[:desc.NoOfColumns], len(rows), func(int) bool { return true },
+		func(row, col int) string { return models.GetListDataField(col, rows[row]) })
+	return export.XlsxExport(exp, hdr, path, sheet)
+}
+
+// Resizable window for a report or monitor, centered on the active window
+func newListWindow(title string, width float32, height float32) (*unison.Window, error) {
+	var frame unison.Rect
+	wnd, err := unison.NewWindow(assets.AppName + " - " + title)
+	if err != nil {
+		return nil, err
+	}
+	installDefaultMenus(wnd)
+	if focused := unison.ActiveWindow(); focused != nil {
+		frame = focused.FrameRect()
+	} else {
+		frame = unison.PrimaryDisplay().Usable
+	}
+	content := wnd.Content()
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(5)))
+	frame.X += (frame.Width - width) / 2
+	frame.Y += (frame.Height - height) / 3
+	frame.Width = width
+	frame.Height = height
+	wnd.SetFrameRect(frame.Align())
+	return wnd, nil
+}
+
+// Button bar above a list, buttons are added by the caller, the status label is right aligned
+func newListToolbar() (*unison.Panel, *unison.Label) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	status := unison.NewLabel()
+	status.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	status.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.End,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	panel.AddChild(status)
+	return panel, status
+}
+
+// Adds a button in front of the status label
+func addListButton(toolbar *unison.Panel, title string, svgcontent string, clicked func()) *unison.Button {
+	btn, err := createButton(title, svgcontent)
+	if err != nil {
+		return nil
+	}
+	btn.SetFocusable(false)
+	btn.ClickCallback = clicked
+	toolbar.AddChildAtIndex(btn, len(toolbar.Children())-1)
+	if layout, ok := toolbar.Layout().(*unison.FlexLayout); ok {
+		layout.Columns = len(toolbar.Children())
+	}
+	return btn
+}
+
+// Adds a filter popup in front of the status label, the first item is selected
+func addListPopup(toolbar *unison.Panel, items ...string) *unison.PopupMenu[string] {
+	popup := unison.NewPopupMenu[string]()
+	popup.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	popup.SetLayoutData(align.Middle)
+	popup.SetFocusable(false)
+	popup.AddItem(items...)
+	popup.SelectIndex(0)
+	toolbar.AddChildAtIndex(popup, len(toolbar.Children())-1)
+	if layout, ok := toolbar.Layout().(*unison.FlexLayout); ok {
+		layout.Columns = len(toolbar.Children())
+	}
+	return popup
+}
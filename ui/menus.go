@@ -0,0 +1,190 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Application menus, using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/settings"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+const (
+	viewMenuID = unison.UserBaseID + iota
+	userDataColumnsItemID
+	facetSidebarItemID
+	peopleLimitsItemID
+	fetchAllItemID
+	itemsMenuID
+	markPlayedItemID
+	markUnplayedItemID
+	addFavoriteItemID
+	removeFavoriteItemID
+	editMetadataItemID
+	pendingEditsItemID
+	refreshMetadataItemID
+	itemAccessItemID
+	bulkExportItemID
+	bulkImportItemID
+	serverMenuID
+	sessionsItemID
+	serverInfoItemID
+	activityLogItemID
+	usersItemID
+	libraryAccessItemID
+	reportsMenuID
+	statisticsItemID
+	duplicatesItemID
+	episodesItemID
+	healthItemID
+	peopleItemID
+	diffItemID
+)
+
+type menuEntry struct {
+	id     int
+	title  string
+	key    unison.KeyCode
+	action userDataAction
+}
+
+// Shared by the "Items" menu and the tables' context menu
+var userDataMenuEntries = []menuEntry{
+	{markPlayedItemID, assets.CapMarkPlayed, unison.KeyP, actionMarkPlayed},
+	{markUnplayedItemID, assets.CapMarkUnplayed, unison.KeyU, actionMarkUnplayed},
+	{addFavoriteItemID, assets.CapAddFavorite, unison.KeyF, actionAddFavorite},
+	{removeFavoriteItemID, assets.CapRemoveFavorite, unison.KeyR, actionRemoveFavorite},
+}
+
+// Application menus are inserted right after Unison's standard "Edit" menu
+func insertAppMenus(m unison.Menu) {
+	index := m.Count()
+	for i := 0; i < m.Count(); i++ {
+		if sub := m.ItemAtIndex(i).SubMenu(); sub != nil && sub.ID() == unison.EditMenuID {
+			index = i + 1
+			break
+		}
+	}
+	m.InsertMenu(index, newViewMenu(m.Factory()))
+	m.InsertMenu(index+1, newItemsMenu(m.Factory()))
+	m.InsertMenu(index+2, newReportsMenu(m.Factory()))
+	m.InsertMenu(index+3, newServerMenu(m.Factory()))
+}
+
+func newViewMenu(f unison.MenuFactory) unison.Menu {
+	m := f.NewMenu(viewMenuID, assets.CapView, nil)
+	item := f.NewItem(userDataColumnsItemID, assets.CapUserData, unison.KeyBinding{}, nil,
+		func(item unison.MenuItem) {
+			settings.SetShowUserData(!settings.GetShowUserData())
+			item.SetCheckState(checkState(settings.GetShowUserData()))
+			if tableScrollArea != nil && tableScrollArea.Parent() != nil {
+				showTable()
+			}
+		})
+	item.SetCheckState(checkState(settings.GetShowUserData()))
+	m.InsertItem(-1, item)
+	facetsItem := f.NewItem(facetSidebarItemID, assets.CapFacets, unison.KeyBinding{}, nil,
+		func(item unison.MenuItem) {
+			settings.SetShowFacets(!settings.GetShowFacets())
+			item.SetCheckState(checkState(settings.GetShowFacets()))
+			showFacetSidebar(settings.GetShowFacets())
+		})
+	facetsItem.SetCheckState(checkState(settings.GetShowFacets()))
+	m.InsertItem(-1, facetsItem)
+	m.InsertItem(-1, f.NewItem(peopleLimitsItemID, assets.CapLimits, unison.KeyBinding{}, nil,
+		func(unison.MenuItem) { peopleLimitsDialog() }))
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, f.NewItem(fetchAllItemID, assets.CapFetchAll, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return fetchBtn.Enabled() && len(userViews) > 0 },
+		func(unison.MenuItem) { embyFetchItemsForUser(true) }))
+	return m
+}
+
+func newItemsMenu(f unison.MenuFactory) unison.Menu {
+	m := f.NewMenu(itemsMenuID, assets.CapItems, nil)
+	for _, entry := range userDataMenuEntries {
+		action := entry.action
+		keys := unison.KeyBinding{KeyCode: entry.key, Modifiers: unison.OSMenuCmdModifier() | unison.ShiftModifier}
+		m.InsertItem(-1, f.NewItem(entry.id, entry.title, keys,
+			func(unison.MenuItem) bool { return canChangeUserData() },
+			func(unison.MenuItem) { changeUserData(action) }))
+	}
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, f.NewItem(editMetadataItemID, assets.CapEditMetadata,
+		unison.KeyBinding{KeyCode: unison.KeyE, Modifiers: unison.OSMenuCmdModifier() | unison.ShiftModifier},
+		func(unison.MenuItem) bool { return canEditMetadata() },
+		func(unison.MenuItem) { editMetadataDialog() }))
+	m.InsertItem(-1, f.NewItem(pendingEditsItemID, assets.CapPendingEdits, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return len(stagedEdits) > 0 || editsWindow != nil },
+		func(unison.MenuItem) { editsWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(refreshMetadataItemID, assets.CapRefreshMetadata, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return canRefreshMetadata() },
+		func(unison.MenuItem) { refreshMetadataDialog() }))
+	m.InsertItem(-1, f.NewItem(itemAccessItemID, assets.CapItemAccess, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return canShowItemAccess() },
+		func(unison.MenuItem) { itemAccessDisplay() }))
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, f.NewItem(bulkExportItemID, assets.CapBulkExport, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return canExportBulkEdit() },
+		func(unison.MenuItem) { bulkEditExport() }))
+	m.InsertItem(-1, f.NewItem(bulkImportItemID, assets.CapBulkImport, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return len(userViews) > 0 },
+		func(unison.MenuItem) { bulkEditImport() }))
+	return m
+}
+
+// Reports are computed from the items fetched for the current view, or for all views
+func newReportsMenu(f unison.MenuFactory) unison.Menu {
+	m := f.NewMenu(reportsMenuID, assets.CapReports, nil)
+	m.InsertItem(-1, f.NewItem(statisticsItemID, assets.CapStatistics, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return hasStatistics(collectionType) },
+		func(unison.MenuItem) { statisticsWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(duplicatesItemID, assets.CapDuplicates, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return len(userViews) > 0 },
+		func(unison.MenuItem) { duplicatesWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(episodesItemID, assets.CapEpisodes, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return collectionType == api.CollectionTVShows && len(itemCache) > 0 },
+		func(unison.MenuItem) { episodesWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(healthItemID, assets.CapHealth, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return len(itemCache) > 0 },
+		func(unison.MenuItem) { healthWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(peopleItemID, assets.CapPeople, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return len(userViews) > 0 },
+		func(unison.MenuItem) { peopleWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(diffItemID, assets.CapSnapshotDiff, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return canShowDiff() },
+		func(unison.MenuItem) { diffWindowDisplay() }))
+	return m
+}
+
+func newServerMenu(f unison.MenuFactory) unison.Menu {
+	m := f.NewMenu(serverMenuID, assets.CapServerMenu, nil)
+	m.InsertItem(-1, f.NewItem(sessionsItemID, assets.CapSessions, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return api.EmbySession.AccessToken != "" },
+		func(unison.MenuItem) { sessionsWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(serverInfoItemID, assets.CapServerInfo, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return api.EmbySession.AccessToken != "" },
+		func(unison.MenuItem) { serverWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(activityLogItemID, assets.CapActivityLog, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return api.EmbySession.AccessToken != "" },
+		func(unison.MenuItem) { activityWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(usersItemID, assets.CapUsers, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return api.IsAdministratorInt() },
+		func(unison.MenuItem) { usersWindowDisplay() }))
+	m.InsertItem(-1, f.NewItem(libraryAccessItemID, assets.CapLibraryAccess, unison.KeyBinding{},
+		func(unison.MenuItem) bool { return api.IsAdministratorInt() },
+		func(unison.MenuItem) { accessWindowDisplay() }))
+	return m
+}
+
+func checkState(on bool) check.Enum {
+	if on {
+		return check.On
+	}
+	return check.Off
+}
@@ -0,0 +1,420 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata editing, in the table's cells or a dialog: edits are staged first, then reviewed and committed to the server (or discarded)
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"strconv"
+)
+
+const (
+	editFieldWidth     float32 = 400
+	editOverviewHeight float32 = 120
+	editsWindowWidth   float32 = 1000
+	editsWindowHeight  float32 = 400
+)
+
+var fieldCaptions = map[string]string{
+	api.FieldName:           assets.CapFieldTitle,
+	api.FieldOriginalTitle:  assets.CapFieldOriginalTitle,
+	api.FieldSortName:       assets.CapFieldSortTitle,
+	api.FieldProductionYear: assets.CapFieldYear,
+	api.FieldGenres:         assets.CapFieldGenres,
+	api.FieldTags:           assets.CapFieldTags,
+	api.FieldOfficialRating: assets.CapFieldRating,
+	api.FieldOverview:       assets.CapFieldOverview,
+}
+
+// Edits waiting for review, in the order they were made
+var stagedEdits []models.MetadataEdit
+
+var editsWindow *unison.Window
+var editsTable *unison.Table[*models.ListRow]
+var editsStatus *unison.Label
+
+// Only items of the video views can be edited, one at a time
+func selectedEditItem() (api.BaseItemDto, bool) {
+	switch collectionType {
+	case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos:
+	default:
+		return api.BaseItemDto{}, false
+	}
+	targets := selectedUserDataTargets()
+	if len(targets) != 1 {
+		return api.BaseItemDto{}, false
+	}
+	d, ok := itemCache[targets[0].itemId]
+	return d, ok
+}
+
+func canEditMetadata() bool {
+	_, ok := selectedEditItem()
+	return ok
+}
+
+// The value staged for the field, if any
+func stagedValue(itemId string, field string) (string, bool) {
+	for _, e := range stagedEdits {
+		if e.ItemId == itemId && e.Field == field {
+			return e.New, true
+		}
+	}
+	return "", false
+}
+
+// Replaces an edit of the same item & field, edits back to the current value are dropped
+func stageEdit(edit models.MetadataEdit) {
+	for i, e := range stagedEdits {
+		if e.Key() == edit.Key() {
+			stagedEdits = append(stagedEdits[:i], stagedEdits[i+1:]...)
+			break
+		}
+	}
+	if edit.New != edit.Old {
+		stagedEdits = append(stagedEdits, edit)
+	}
+}
+
+func editMetadataDialog() {
+	d, ok := selectedEditItem()
+	if !ok {
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	if d.LockData {
+		note := unison.NewLabel()
+		note.Font = unison.LabelFont
+		note.SetTitle(assets.TxtItemLocked)
+		note.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+		panel.AddChild(note)
+	}
+	values := make(map[string]func() string)
+	for _, field := range api.EditableFields {
+		lbl := unison.NewLabel()
+		lbl.Font = unison.LabelFont
+		lbl.SetTitle(fieldCaptions[field])
+		if api.FieldLocked(d, field) {
+			lbl.SetTitle(fieldCaptions[field] + " " + assets.CapLocked)
+		}
+		lbl.SetLayoutData(&unison.FlexLayoutData{HAlign: align.End, VAlign: align.Start})
+		panel.AddChild(lbl)
+		var fld *unison.Field
+		if field == api.FieldOverview {
+			fld = unison.NewMultiLineField()
+			fld.SetWrap(true)
+		} else {
+			fld = unison.NewField()
+		}
+		fld.Font = unison.FieldFont
+		fld.MinimumTextWidth = editFieldWidth
+		if value, ok := stagedValue(d.Id, field); ok {
+			fld.SetText(value)
+		} else {
+			fld.SetText(api.EditableValue(d, field))
+		}
+		fld.SetEnabled(!api.FieldLocked(d, field))
+		if field == api.FieldOverview {
+			scroller := unison.NewScrollPanel()
+			scroller.SetContent(fld, behavior.Fill, behavior.Unmodified)
+			scroller.SetLayoutData(&unison.FlexLayoutData{
+				SizeHint: unison.NewSize(editFieldWidth, editOverviewHeight),
+				HAlign:   align.Fill,
+				VAlign:   align.Fill,
+			})
+			unison.InstallDefaultFieldBorder(fld, scroller)
+			panel.AddChild(scroller)
+		} else {
+			panel.AddChild(fld)
+		}
+		values[field] = fld.Text
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo(), unison.NewCancelButtonInfo()},
+		unison.NotResizableWindowOption())
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	dialog.Window().SetTitle(assets.CapEditMetadata + " - " + api.ItemTitle(d))
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	for _, field := range api.EditableFields {
+		if api.FieldLocked(d, field) {
+			continue
+		}
+		if err = api.ValidateValue(field, api.NormalizeValue(field, values[field]())); err != nil {
+			DialogToDisplaySystemError(assets.ErrInvalidValue, err)
+			return
+		}
+	}
+	for _, field := range api.EditableFields {
+		if api.FieldLocked(d, field) {
+			continue
+		}
+		stageEdit(newMetadataEdit(d, field, values[field]()))
+	}
+	editsWindowDisplay()
+}
+
+func newMetadataEdit(d api.BaseItemDto, field string, value string) models.MetadataEdit {
+	return models.MetadataEdit{
+		ItemId:  d.Id,
+		Title:   api.ItemTitle(d),
+		Field:   field,
+		Caption: fieldCaptions[field],
+		Old:     api.EditableValue(d, field),
+		New:     api.NormalizeValue(field, value),
+	}
+}
+
+// The columns of the current table
+func editTableColumns() []models.ColumnDescription {
+	switch collectionType {
+	case api.CollectionMovies:
+		return tableColumns(models.MovieTableDescription)
+	case api.CollectionTVShows:
+		return tableColumns(models.TVShowTableDescription)
+	case api.CollectionHomeVideos:
+		return tableColumns(models.HomeVideoTableDescription)
+	default:
+		return nil
+	}
+}
+
+// The field edited in a column of the tables; the name columns of TV shows are those of the row's type
+func editableColumnField(caption string, d api.BaseItemDto) (string, bool) {
+	switch caption {
+	case "Title":
+		return api.FieldName, true
+	case "Series":
+		return api.FieldName, d.Type_ == api.SeriesType
+	case "Season":
+		return api.FieldName, d.Type_ == api.SeasonType
+	case "Episode":
+		return api.FieldName, d.Type_ == api.EpisodeType
+	case "Original Title":
+		return api.FieldOriginalTitle, true
+	case "Year":
+		return api.FieldProductionYear, true
+	case "Genre":
+		return api.FieldGenres, true
+	default:
+		return "", false
+	}
+}
+
+// Double click on an editable cell: the cell is edited in place, Return stages the edit, Escape cancels it
+// Fields locked are not edited, all the other fields are edited in the dialog (see editMetadataDialog)
+func editCell[T unison.TableRowConstraint[T]](table *unison.Table[T], where unison.Point) bool {
+	row, col := table.OverRow(where.Y), table.OverColumn(where.X)
+	columns := editTableColumns()
+	if row < 0 || col < 0 || col >= len(columns) || table.OverColumnDivider(where.X) != -1 ||
+		!table.IsRowOrAnyParentSelected(row) {
+		return false
+	}
+	d, ok := selectedEditItem()
+	if !ok {
+		return false
+	}
+	field, ok := editableColumnField(columns[col].Caption, d)
+	if !ok || api.FieldLocked(d, field) {
+		return false
+	}
+	fld := unison.NewField()
+	fld.Font = unison.FieldFont
+	if value, ok := stagedValue(d.Id, field); ok {
+		fld.SetText(value)
+	} else {
+		fld.SetText(api.EditableValue(d, field))
+	}
+	fld.SetFrameRect(table.CellFrame(row, col))
+	done := false
+	finish := func(stage bool) {
+		if done {
+			return
+		}
+		done = true
+		value := fld.Text()
+		fld.RemoveFromParent()
+		table.MarkForRedraw()
+		if !stage {
+			return
+		}
+		if err := api.ValidateValue(field, api.NormalizeValue(field, value)); err != nil {
+			DialogToDisplaySystemError(assets.ErrInvalidValue, err)
+			return
+		}
+		stageEdit(newMetadataEdit(d, field, value))
+		editsWindowDisplay()
+	}
+	fld.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		switch keyCode {
+		case unison.KeyReturn, unison.KeyNumPadEnter:
+			finish(true)
+			return true
+		case unison.KeyEscape:
+			finish(false)
+			return true
+		default:
+			return fld.DefaultKeyDown(keyCode, mod, repeat)
+		}
+	}
+	lostFocus := fld.LostFocusCallback
+	fld.LostFocusCallback = func() {
+		if lostFocus != nil {
+			lostFocus()
+		}
+		// not removed while the focus changes
+		unison.InvokeTask(func() { finish(true) })
+	}
+	table.AddChild(fld)
+	fld.SelectAll()
+	fld.RequestFocus()
+	table.MarkForRedraw()
+	return true
+}
+
+func editsListData() []models.ListData {
+	data := make([]models.ListData, 0, len(stagedEdits))
+	for _, e := range stagedEdits {
+		data = append(data, e.ListData())
+	}
+	return data
+}
+
+// Review window for the staged edits
+func editsWindowDisplay() {
+	if editsWindow != nil {
+		updateEditsWindow()
+		editsWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapPendingEdits, editsWindowWidth, editsWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	editsWindow = wnd
+	table, scrollArea := newListTable(models.EditTableDescription, editsListData())
+	editsTable = table
+	toolbar, status := newListToolbar()
+	editsStatus = status
+	var commitBtn *unison.Button
+	commitBtn = addListButton(toolbar, assets.CapCommit, assets.IconFetch, func() {
+		if len(stagedEdits) == 0 {
+			return
+		}
+		commitBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSaving)
+		go commitEdits(append([]models.MetadataEdit(nil), stagedEdits...), commitBtn)
+	})
+	addListButton(toolbar, assets.CapDiscard, assets.IconDetails, func() {
+		discard := make(map[string]bool)
+		for _, r := range table.SelectedRows(false) {
+			discard[r.M.Key] = true
+		}
+		kept := make([]models.MetadataEdit, 0, len(stagedEdits))
+		for _, e := range stagedEdits {
+			if !discard[e.Key()] {
+				kept = append(kept, e)
+			}
+		}
+		stagedEdits = kept
+		updateEditsWindow()
+	})
+	addListButton(toolbar, assets.CapDiscardAll, assets.IconDetails, func() {
+		stagedEdits = nil
+		updateEditsWindow()
+	})
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.EditTableDescription, editsListData(), assets.CapPendingEdits)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		editsWindow = nil
+		editsTable = nil
+		editsStatus = nil
+	}
+	updateEditsWindow()
+	wnd.ToFront()
+}
+
+func updateEditsWindow() {
+	if editsWindow == nil {
+		return
+	}
+	setListRows(editsTable, editsListData())
+	editsStatus.SetTitle(assets.CapPendingEdits + ": " + strconv.Itoa(len(stagedEdits)))
+	editsTable.MarkForRedraw()
+	editsStatus.Parent().MarkForLayoutAndRedraw()
+}
+
+// Commits the edits item by item (in the background), edits committed are removed, failed ones stay staged
+func commitEdits(edits []models.MetadataEdit, commitBtn *unison.Button) {
+	items := make([]string, 0)
+	values := make(map[string]map[string]string)
+	for _, e := range edits {
+		if values[e.ItemId] == nil {
+			values[e.ItemId] = make(map[string]string)
+			items = append(items, e.ItemId)
+		}
+		values[e.ItemId][e.Field] = e.New
+	}
+	updated := make([]api.BaseItemDto, 0)
+	failed := make(map[string]string)
+	for _, id := range items {
+		d, err := api.ApplyEditsInt(id, values[id])
+		if err != nil {
+			failed[id] = err.Error()
+			continue
+		}
+		updated = append(updated, d)
+	}
+	unison.InvokeTask(func() {
+		// edits staged meanwhile are kept, unless they are the very edits committed
+		kept := make([]models.MetadataEdit, 0, len(stagedEdits))
+		for _, e := range stagedEdits {
+			value, ok := values[e.ItemId][e.Field]
+			if ok && value == e.New {
+				if failed[e.ItemId] == "" {
+					continue
+				}
+				e.Status = failed[e.ItemId]
+			}
+			kept = append(kept, e)
+		}
+		stagedEdits = kept
+		changed := false
+		for _, d := range updated {
+			changed = updateCachedItem(d) || changed
+		}
+		if changed {
+			updateTableRows()
+		}
+		if commitBtn != nil {
+			commitBtn.SetEnabled(true)
+		}
+		updateEditsWindow()
+		if len(failed) > 0 {
+			DialogToDisplayErrorMessage(assets.ErrCommitFailed, strconv.Itoa(len(failed))+" / "+strconv.Itoa(len(items)))
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// People browser window: everybody credited in the video views, with the filmography of the person selected
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"strconv"
+)
+
+const (
+	peopleWindowWidth  float32 = 1300
+	peopleWindowHeight float32 = 700
+	peopleListWidth    float32 = 420
+)
+
+var peopleWindow *unison.Window
+var peopleViews []api.ViewItems
+var peopleData []models.ListData
+var filmographyData []models.ListData
+
+func peopleWindowDisplay() {
+	if peopleWindow != nil {
+		peopleWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapPeople, peopleWindowWidth, peopleWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	peopleWindow = wnd
+	peopleViews, peopleData, filmographyData = nil, nil, nil
+	people, peopleScroller := newListTable(models.PeopleTableDescription, peopleData)
+	peopleScroller.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(peopleListWidth, 0),
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+		VGrab:    true,
+	})
+	films, filmsScroller := newListTable(models.FilmographyTableDescription, filmographyData)
+	lists := unison.NewPanel()
+	lists.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	lists.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	lists.AddChild(peopleScroller)
+	lists.AddChild(filmsScroller)
+	toolbar, status := newListToolbar()
+	var refreshBtn, serverBtn *unison.Button
+	selectedPerson := func() (models.ListData, bool) {
+		for _, r := range people.SelectedRows(false) {
+			return r.M, true
+		}
+		return models.ListData{}, false
+	}
+	showFilmography := func(data []models.ListData) {
+		filmographyData = data
+		setListRows(films, filmographyData)
+		films.MarkForRedraw()
+	}
+	search := func() {
+		refreshBtn.SetEnabled(false)
+		serverBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go findPeople(userViews, people, status, refreshBtn)
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, search)
+	// Emby's PersonIds filter covers all libraries, not only the views fetched
+	serverBtn = addListButton(toolbar, assets.CapSearchServer, assets.IconDetails, func() {
+		person, ok := selectedPerson()
+		if !ok {
+			return
+		}
+		serverBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go func() {
+			dto, err := api.UserGetItemsByPersonInt(person.Key)
+			data := api.GetFilmographyDisplayData(person.Key, []api.ViewItems{{View: assets.CapServerMenu, Items: dto}})
+			unison.InvokeTask(func() {
+				if peopleWindow != wnd {
+					return
+				}
+				serverBtn.SetEnabled(true)
+				if err != nil {
+					status.SetTitle(assets.ErrFetchItemsFailed + " " + err.Error())
+				} else {
+					showFilmography(data)
+					status.SetTitle(person.Fields[0] + ": " + strconv.Itoa(len(data)) + " " + assets.CapItems)
+				}
+				status.Parent().MarkForLayoutAndRedraw()
+			})
+		}()
+	})
+	serverBtn.SetEnabled(false)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.PeopleTableDescription, peopleData, assets.CapPeople)
+	})
+	addListButton(toolbar, assets.CapFilmography, assets.IconExport, func() {
+		person, ok := selectedPerson()
+		if ok {
+			exportList(models.FilmographyTableDescription, filmographyData, person.Fields[0])
+		}
+	})
+	people.SelectionChangedCallback = func() {
+		person, ok := selectedPerson()
+		serverBtn.SetEnabled(ok && refreshBtn.Enabled())
+		if !ok {
+			showFilmography(nil)
+			return
+		}
+		showFilmography(api.GetFilmographyDisplayData(person.Key, peopleViews))
+	}
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(lists)
+	wnd.WillCloseCallback = func() {
+		peopleWindow = nil
+		peopleViews = nil
+	}
+	wnd.ToFront()
+	search()
+}
+
+// Runs in the background, see fetchVideoViews
+func findPeople(sources []api.UserView, table *unison.Table[*models.ListRow], status *unison.Label,
+	refreshBtn *unison.Button) {
+	views, failed := fetchVideoViews(sources)
+	data := api.GetPeopleDisplayData(views)
+	unison.InvokeTask(func() {
+		if peopleWindow == nil {
+			return
+		}
+		peopleViews = views
+		peopleData = data
+		setListRows(table, peopleData)
+		refreshBtn.SetEnabled(true)
+		if failed != nil {
+			status.SetTitle(assets.ErrFetchItemsFailed + " " + failed.Error())
+		} else {
+			status.SetTitle(assets.CapPeople + ": " + strconv.Itoa(len(peopleData)))
+		}
+		if table.SelectionChangedCallback != nil {
+			table.SelectionChangedCallback()
+		}
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}
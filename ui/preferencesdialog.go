@@ -134,6 +134,7 @@ func saveSettings() {
 		s := settings.GetPreferences()
 		api.InitApiPreferences(s.EmbySecure, s.EmbyServer, s.EmbyPort, s.EmbyUser, string(s.EmbyPassword))
 		authBtn.SetEnabled(true) // enable button for authorization
+		loadCachedViews()        // the server or user may have changed
 	}
 }
 
@@ -0,0 +1,239 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Metadata refresh of selected items: the server refreshes in the background, completion is tracked by polling
+// Items refreshed are updated in the table in place
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"strconv"
+	"time"
+)
+
+const (
+	refreshWindowWidth  float32 = 900
+	refreshWindowHeight float32 = 400
+)
+
+const refreshStatusColumn = 3
+
+var refreshModeCaptions = map[api.RefreshMode]string{
+	api.RefreshMissing:         assets.CapRefreshMissing,
+	api.RefreshReplaceImages:   assets.CapRefreshImages,
+	api.RefreshReplaceMetadata: assets.CapRefreshAll,
+}
+
+// Refresh progress, kept when the window is closed; items being refreshed cannot be cleared
+var refreshData []models.ListData
+var refreshRunning = make(map[string]bool)
+
+var refreshWindow *unison.Window
+var refreshTable *unison.Table[*models.ListRow]
+var refreshStatus *unison.Label
+
+// Items of the video views selected
+func refreshTargets() []api.BaseItemDto {
+	switch collectionType {
+	case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos:
+	default:
+		return nil
+	}
+	result := make([]api.BaseItemDto, 0)
+	for _, t := range selectedUserDataTargets() {
+		if d, ok := itemCache[t.itemId]; ok {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func canRefreshMetadata() bool {
+	return len(refreshTargets()) > 0
+}
+
+func refreshMetadataDialog() {
+	items := refreshTargets()
+	if len(items) == 0 {
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	lbl := unison.NewLabel()
+	lbl.Font = unison.LabelFont
+	lbl.SetTitle(assets.CapRefreshOption)
+	lbl.SetLayoutData(&unison.FlexLayoutData{HAlign: align.End, VAlign: align.Middle})
+	panel.AddChild(lbl)
+	popup := unison.NewPopupMenu[string]()
+	for _, mode := range api.RefreshModes {
+		popup.AddItem(refreshModeCaptions[mode])
+	}
+	popup.SelectIndex(0)
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(nil, nil, panel,
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo(), unison.NewCancelButtonInfo()},
+		unison.NotResizableWindowOption())
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	dialog.Window().SetTitle(assets.CapRefreshMetadata + " - " + strconv.Itoa(len(items)) + " " + assets.CapItems)
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	refreshMetadata(items, api.RefreshModes[popup.SelectedIndex()])
+}
+
+func refreshMetadata(items []api.BaseItemDto, mode api.RefreshMode) {
+	ids := make([]string, 0, len(items))
+	for _, d := range items {
+		if refreshRunning[d.Id] {
+			continue
+		}
+		refreshRunning[d.Id] = true
+		ids = append(ids, d.Id)
+		row := models.ListData{
+			Key:    d.Id,
+			Fields: []string{api.ItemTitle(d), d.Type_, refreshModeCaptions[mode], assets.CapQueued},
+		}
+		replaced := false
+		for i := range refreshData {
+			if refreshData[i].Key == d.Id {
+				refreshData[i] = row
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			refreshData = append(refreshData, row)
+		}
+	}
+	refreshWindowDisplay()
+	if len(ids) > 0 {
+		go runRefresh(ids, mode)
+	}
+}
+
+// Runs in the background: all items are queued first, then polled together until refreshed or timed out
+func runRefresh(ids []string, mode api.RefreshMode) {
+	pending := make(map[string]api.BaseItemDto)
+	for _, id := range ids {
+		before, err := api.UserGetItemInt(id)
+		if err == nil {
+			err = api.RefreshItemInt(id, mode)
+		}
+		if err != nil {
+			refreshFinished(id, err.Error(), nil)
+			continue
+		}
+		pending[id] = before
+		setRefreshStatus(id, assets.CapRefreshing)
+	}
+	deadline := time.Now().Add(api.RefreshTimeout)
+	for len(pending) > 0 && time.Now().Before(deadline) {
+		time.Sleep(api.RefreshPollInterval)
+		for id, before := range pending {
+			after, err := api.UserGetItemInt(id)
+			if err != nil || !api.RefreshCompleted(before, after) {
+				continue
+			}
+			delete(pending, id)
+			refreshFinished(id, assets.CapDone, &after)
+		}
+	}
+	// a refresh that found nothing to change doesn't save the item
+	for id := range pending {
+		refreshFinished(id, assets.CapNoChange, nil)
+	}
+}
+
+func setRefreshStatus(id string, status string) {
+	unison.InvokeTask(func() {
+		setRefreshRow(id, status)
+		updateRefreshWindow()
+	})
+}
+
+// The row of the table is updated if the item is still part of the current view
+func refreshFinished(id string, status string, d *api.BaseItemDto) {
+	unison.InvokeTask(func() {
+		setRefreshRow(id, status)
+		delete(refreshRunning, id)
+		updateRefreshWindow()
+		if d != nil && updateCachedItem(*d) {
+			updateTableRows()
+		}
+	})
+}
+
+func setRefreshRow(id string, status string) {
+	for i := range refreshData {
+		if refreshData[i].Key == id {
+			refreshData[i].Fields[refreshStatusColumn] = status
+			break
+		}
+	}
+}
+
+func refreshWindowDisplay() {
+	if refreshWindow != nil {
+		updateRefreshWindow()
+		refreshWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapMetadataRefresh, refreshWindowWidth, refreshWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	refreshWindow = wnd
+	table, scrollArea := newListTable(models.RefreshTableDescription, refreshData)
+	refreshTable = table
+	toolbar, status := newListToolbar()
+	refreshStatus = status
+	addListButton(toolbar, assets.CapClear, assets.IconDetails, func() {
+		kept := make([]models.ListData, 0, len(refreshRunning))
+		for _, r := range refreshData {
+			if refreshRunning[r.Key] {
+				kept = append(kept, r)
+			}
+		}
+		refreshData = kept
+		updateRefreshWindow()
+	})
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.RefreshTableDescription, refreshData, assets.CapMetadataRefresh)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		refreshWindow = nil
+		refreshTable = nil
+		refreshStatus = nil
+	}
+	updateRefreshWindow()
+	wnd.ToFront()
+}
+
+func updateRefreshWindow() {
+	if refreshWindow == nil {
+		return
+	}
+	setListRows(refreshTable, refreshData)
+	refreshStatus.SetTitle(assets.CapRefreshing + " " + strconv.Itoa(len(refreshRunning)) + " / " +
+		strconv.Itoa(len(refreshData)))
+	refreshTable.MarkForRedraw()
+	refreshStatus.Parent().MarkForLayoutAndRedraw()
+}
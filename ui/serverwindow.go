@@ -0,0 +1,178 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Server window: system information, libraries with their paths & library scans, scheduled tasks with progress
+// Libraries & tasks are polled while the window is open
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"time"
+)
+
+const (
+	serverPollInterval         = 5 * time.Second
+	serverWindowWidth  float32 = 1200
+	serverWindowHeight float32 = 800
+	serverInfoHeight   float32 = 200
+	libraryListHeight  float32 = 180
+)
+
+// System info of the server connected to, fetched after authentication
+var serverInfo api.SystemInfo
+
+var serverWindow *unison.Window
+var serverInfoData []models.ListData
+var libraryData []models.ListData
+var taskData []models.ListData
+
+// Runs in the background, the server's name & version go into the files exported
+func fetchServerInfo() {
+	info, err := api.GetSystemInfoInt()
+	if err != nil {
+		return
+	}
+	unison.InvokeTask(func() {
+		serverInfo = info
+		export.SetServerInfo(info.ServerName, info.Version)
+	})
+}
+
+func serverWindowDisplay() {
+	if serverWindow != nil {
+		serverWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapServerInfo, serverWindowWidth, serverWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	serverWindow = wnd
+	serverInfoData, libraryData, taskData = api.GetServerInfoDisplayData(serverInfo), nil, nil
+	info, infoScroller := newListTable(models.ServerInfoTableDescription, serverInfoData)
+	infoScroller.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(0, serverInfoHeight),
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+		HGrab:    true,
+	})
+	libraries, librariesScroller := newListTable(models.LibraryTableDescription, libraryData)
+	librariesScroller.SetLayoutData(&unison.FlexLayoutData{
+		SizeHint: unison.NewSize(0, libraryListHeight),
+		HAlign:   align.Fill,
+		VAlign:   align.Fill,
+		HGrab:    true,
+	})
+	tasks, tasksScroller := newListTable(models.TaskTableDescription, taskData)
+	toolbar, status := newListToolbar()
+	stop := make(chan struct{})
+	poll := func() {
+		go pollServer(info, libraries, tasks, status)
+	}
+	scanned := func(err error) {
+		unison.InvokeTask(func() {
+			if serverWindow != wnd {
+				return
+			}
+			if err != nil {
+				status.SetTitle(assets.ErrScanFailed + " " + err.Error())
+				status.Parent().MarkForLayoutAndRedraw()
+				return
+			}
+			poll()
+		})
+	}
+	addListButton(toolbar, assets.CapRefresh, assets.IconFetch, poll)
+	addListButton(toolbar, assets.CapScanAll, assets.IconFetch, func() {
+		go func() { scanned(api.RefreshLibraryInt()) }()
+	})
+	var scanBtn *unison.Button
+	scanBtn = addListButton(toolbar, assets.CapScanLibrary, assets.IconFetch, func() {
+		for _, r := range libraries.SelectedRows(false) {
+			id := r.M.Key
+			go func() { scanned(api.RefreshItemInt(id, api.RefreshScan)) }()
+		}
+	})
+	scanBtn.SetEnabled(false)
+	libraries.SelectionChangedCallback = func() {
+		scanBtn.SetEnabled(libraries.HasSelection())
+	}
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.TaskTableDescription, taskData, assets.CapTasks)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(infoScroller)
+	content.AddChild(librariesScroller)
+	content.AddChild(tasksScroller)
+	wnd.WillCloseCallback = func() {
+		close(stop)
+		serverWindow = nil
+	}
+	wnd.ToFront()
+	poll()
+	go func() {
+		ticker := time.NewTicker(serverPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				pollServer(info, libraries, tasks, status)
+			}
+		}
+	}()
+}
+
+// Runs in the background, the tables are updated on the UI thread
+// Libraries & tasks need an administrator, the system info is shown anyway
+func pollServer(info, libraries, tasks *unison.Table[*models.ListRow], status *unison.Label) {
+	system, err := api.GetSystemInfoInt()
+	var folders []api.VirtualFolderInfo
+	var scheduled []api.TaskInfo
+	if err == nil {
+		folders, err = api.GetVirtualFoldersInt()
+	}
+	if err == nil {
+		scheduled, err = api.GetScheduledTasksInt()
+	}
+	unison.InvokeTask(func() {
+		if serverWindow == nil {
+			return
+		}
+		if system.Id != "" {
+			serverInfo = system
+			export.SetServerInfo(system.ServerName, system.Version)
+			serverInfoData = api.GetServerInfoDisplayData(system)
+			setListRows(info, serverInfoData)
+			info.MarkForRedraw()
+		}
+		if err != nil {
+			status.SetTitle(assets.ErrFetchServer + " " + err.Error())
+			status.Parent().MarkForLayoutAndRedraw()
+			return
+		}
+		libraryData = api.GetLibraryDisplayData(folders)
+		setListRows(libraries, libraryData)
+		taskData = api.GetTaskDisplayData(scheduled)
+		setListRows(tasks, taskData)
+		title := assets.CapUpdated + " " + time.Now().Format("15:04:05")
+		if api.TasksRunning(scheduled) {
+			title = assets.CapTasksRunning + "   " + title
+		}
+		status.SetTitle(title)
+		libraries.MarkForRedraw()
+		tasks.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}
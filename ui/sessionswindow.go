@@ -0,0 +1,89 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Sessions window: clients, now playing & transcoding, polled while the window is open
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"time"
+)
+
+const (
+	sessionsPollInterval         = 5 * time.Second
+	sessionsWindowWidth  float32 = 1200
+	sessionsWindowHeight float32 = 400
+)
+
+var sessionsWindow *unison.Window
+var sessionsData []models.ListData
+
+func sessionsWindowDisplay() {
+	if sessionsWindow != nil {
+		sessionsWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapSessions, sessionsWindowWidth, sessionsWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	sessionsWindow = wnd
+	sessionsData = nil
+	table, scrollArea := newListTable(models.SessionTableDescription, sessionsData)
+	toolbar, status := newListToolbar()
+	stop := make(chan struct{})
+	update := func() {
+		go pollSessions(table, status)
+	}
+	addListButton(toolbar, assets.CapRefresh, assets.IconFetch, update)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.SessionTableDescription, sessionsData, assets.CapSessions)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		close(stop)
+		sessionsWindow = nil
+	}
+	wnd.ToFront()
+	update()
+	go func() {
+		ticker := time.NewTicker(sessionsPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				pollSessions(table, status)
+			}
+		}
+	}()
+}
+
+// Runs in the background, the table is updated on the UI thread
+func pollSessions(table *unison.Table[*models.ListRow], status *unison.Label) {
+	sessions, err := api.GetSessionsInt()
+	unison.InvokeTask(func() {
+		if sessionsWindow == nil {
+			return
+		}
+		if err != nil {
+			status.SetTitle(assets.ErrFetchSessions + " " + err.Error())
+			return
+		}
+		sessionsData = api.GetSessionDisplayData(sessions)
+		setListRows(table, sessionsData)
+		status.SetTitle(assets.CapUpdated + " " + time.Now().Format("15:04:05"))
+		table.MarkForRedraw()
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}
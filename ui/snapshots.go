@@ -0,0 +1,142 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Offline snapshots: views fetched are stored locally and shown at once, also without a connection to the server
+// Fetching again only queries the items changed since the latest snapshot (incremental sync)
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/settings"
+	"Emby_Explorer/snapshot"
+	"time"
+)
+
+// Time the data displayed was fetched, zero if fetched just now
+var snapshotTaken time.Time
+
+func snapshotKey() snapshot.Key {
+	p := settings.GetPreferences()
+	return snapshot.NewKey(p.EmbyServer, p.EmbyPort, p.EmbyUser)
+}
+
+// Live TV changes by the minute, it isn't kept
+func canSnapshot(view api.UserView) bool {
+	switch view.CollectionType {
+	case api.CollectionMovies, api.CollectionTVShows, api.CollectionHomeVideos, api.CollectionPhotos:
+		return true
+	default:
+		return false
+	}
+}
+
+// Shows the views of the server & user configured as cached, before authentication
+func loadCachedViews() {
+	views, _ := snapshot.LoadViews(snapshotKey())
+	setViews(views)
+}
+
+// Fills the views popup, selecting the first view switches to it (see switchView)
+func setViews(views []api.UserView) {
+	userViews = views
+	viewsPopupMenu.RemoveAllItems()
+	for _, v := range userViews {
+		viewsPopupMenu.AddItem(v.Name)
+	}
+	if len(userViews) > 0 {
+		viewsPopupMenu.SelectIndex(0)
+	} else {
+		collectionType = ""
+		cacheItems(nil)
+		setSnapshotTitle(time.Time{})
+		setLogoPanel()
+	}
+	viewsPopupMenu.MarkForRedraw()
+}
+
+// Runs in the background, errors are ignored as a snapshot is only a cache
+func saveViews(views []api.UserView) {
+	_ = snapshot.SaveViews(snapshotKey(), views)
+}
+
+func saveSnapshot(view api.UserView, result syncResult) {
+	if !canSnapshot(view) {
+		return
+	}
+	// the items are copied, as the view's items are updated on the UI thread meanwhile (see updateCachedItem)
+	items := make([]api.BaseItemDto, len(result.items))
+	copy(items, result.items)
+	s := snapshot.Snapshot{Key: snapshotKey(), View: view, Taken: result.taken, LastFull: result.lastFull,
+		Items: items}
+	go func() { _ = snapshot.Save(s) }()
+}
+
+type syncResult struct {
+	items    []api.BaseItemDto
+	changed  map[string]bool // added or changed since the latest snapshot
+	taken    time.Time
+	lastFull time.Time
+}
+
+// Merges the items changed since the latest snapshot into it; all items are fetched if full, if there is no
+// snapshot, or if the last full fetch is older than api.ReconcileInterval, as deleted items are found that way only
+func syncItems(view api.UserView, full bool) (syncResult, error) {
+	result := syncResult{taken: time.Now(), changed: make(map[string]bool)}
+	previous, err := snapshot.Latest(snapshotKey(), view.Id)
+	if !canSnapshot(view) || err != nil {
+		previous = snapshot.Snapshot{}
+	}
+	var dto []api.BaseItemDto
+	if previous.Items != nil && !full && result.taken.Sub(previous.LastFull) < api.ReconcileInterval {
+		dto, err = api.UserGetItemsSinceInt(view.Id, view.CollectionType, previous.Taken.Add(-api.SyncMargin))
+		if err != nil {
+			return result, err
+		}
+		result.items = api.MergeItems(previous.Items, dto)
+		result.lastFull = previous.LastFull
+	} else {
+		result.items, err = api.UserGetItenmsInt(view.Id, view.CollectionType)
+		if err != nil {
+			return result, err
+		}
+		result.lastFull = result.taken
+	}
+	// without a snapshot everything would be new, nothing is highlighted
+	if previous.Items != nil {
+		result.changed = api.ChangedItems(previous.Items, result.items)
+	}
+	return result, nil
+}
+
+// Displays the latest snapshot of the view, false if there is none
+func showSnapshot(view api.UserView) bool {
+	if !canSnapshot(view) {
+		return false
+	}
+	s, err := snapshot.Latest(snapshotKey(), view.Id)
+	if err != nil {
+		return false
+	}
+	cacheItems(s.Items)
+	if view.CollectionType == api.CollectionPhotos {
+		photoGridMode = false
+	}
+	setDisplayData(view.CollectionType, s.Items)
+	showTable()
+	setSnapshotTitle(s.Taken)
+	return true
+}
+
+func setSnapshotTitle(taken time.Time) {
+	snapshotTaken = taken
+	title := assets.AppName + " " + assets.AppVersion
+	if !taken.IsZero() {
+		title = title + " - " + assets.CapSnapshotOf + " " + taken.Local().Format(api.DateFormat)
+		if api.EmbySession.AccessToken == "" {
+			title = title + " (" + assets.CapOffline + ")"
+		}
+	}
+	mainWindow.SetTitle(title)
+}
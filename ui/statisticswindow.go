@@ -0,0 +1,168 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Statistics window: bar charts per category, with a table as alternative; also a sheet of the view's export
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"strconv"
+	"strings"
+)
+
+const (
+	statisticsWindowWidth  float32 = 860
+	statisticsWindowHeight float32 = 700
+	chartWidth             float32 = 400
+	chartBarHeight         float32 = 16
+	chartLabelWidth        float32 = 130
+	chartCountWidth        float32 = 50
+	chartMaxBars                   = 10
+)
+
+var statisticsWindow *unison.Window
+
+func statisticsWindowDisplay() {
+	if !hasStatistics(collectionType) {
+		return
+	}
+	if statisticsWindow != nil {
+		statisticsWindow.Dispose()
+	}
+	view := userViews[viewsPopupMenu.SelectedIndex()]
+	stats := viewStatistics()
+	title := assets.CapStatistics + " - " + view.Name
+	wnd, err := newListWindow(title, statisticsWindowWidth, statisticsWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	statisticsWindow = wnd
+	data := stats.ListData()
+	charts := newStatisticsCharts(stats)
+	_, list := newListTable(models.StatisticsTableDescription, data)
+	toolbar, status := newListToolbar()
+	status.SetTitle(assets.CapItems + ": " + strconv.Itoa(stats.Items) + "   " + stats.Runtime + "   " + stats.Size)
+	content := wnd.Content()
+	addListButton(toolbar, assets.CapTable, assets.IconGrid, func() {
+		// charts & table are alternatives, both fill the rest of the window
+		if charts.Parent() != nil {
+			content.RemoveChild(charts)
+			content.AddChild(list)
+		} else {
+			content.RemoveChild(list)
+			content.AddChild(charts)
+		}
+		content.MarkForLayoutAndRedraw()
+	})
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.StatisticsTableDescription, data, assets.CapStatistics)
+	})
+	content.AddChild(toolbar)
+	content.AddChild(charts)
+	wnd.WillCloseCallback = func() {
+		statisticsWindow = nil
+	}
+	wnd.ToFront()
+}
+
+func viewStatistics() models.Statistics {
+	dto := make([]api.BaseItemDto, 0, len(itemCache))
+	for _, d := range itemCache {
+		dto = append(dto, d)
+	}
+	return api.GetStatistics(dto)
+}
+
+// The statistics are exported as a sheet of the view's export as well, live TV has none
+// Statistics cover the video views only, photos & live TV have no runtime, codecs or genres
+func hasStatistics(collection string) bool {
+	return len(itemCache) > 0 && collection != api.CollectionPhotos && !strings.HasPrefix(collection, api.CollectionLiveTv)
+}
+
+func statisticsSheet(collection string) (export.Sheet, bool) {
+	if !hasStatistics(collection) {
+		return export.Sheet{}, false
+	}
+	desc := models.StatisticsTableDescription
+	rows := flattenList(viewStatistics().ListData())
+	hdr, exp := buildExportData(desc.Columns[:desc.NoOfColumns], len(rows), func(int) bool { return true },
+		func(row, col int) string { return models.GetListDataField(col, rows[row]) })
+	return export.Sheet{Name: assets.CapStatistics, Header: hdr, Data: exp}, true
+}
+
+func newStatisticsCharts(stats models.Statistics) *unison.ScrollPanel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: 20,
+		VSpacing: 20,
+	})
+	panel.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(10)))
+	for _, g := range stats.Groups {
+		panel.AddChild(newBarChart(g))
+	}
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(panel, behavior.Fill, behavior.Unmodified)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	return scroller
+}
+
+// Horizontal bars for the most frequent values, the remaining ones are summed up as "other"
+func newBarChart(group models.StatisticsGroup) *unison.Panel {
+	entries := group.Entries
+	if len(entries) > chartMaxBars {
+		other := models.StatisticsEntry{Name: assets.CapOther}
+		for _, e := range entries[chartMaxBars-1:] {
+			other.Count += e.Count
+		}
+		entries = append(append([]models.StatisticsEntry{}, entries[:chartMaxBars-1]...), other)
+	}
+	maxCount := 1
+	for _, e := range entries {
+		if e.Count > maxCount {
+			maxCount = e.Count
+		}
+	}
+	size := unison.NewSize(chartWidth, chartBarHeight*float32(len(entries)+2))
+	chart := unison.NewPanel()
+	chart.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+		return size, size, size
+	})
+	chart.DrawCallback = func(gc *unison.Canvas, _ unison.Rect) {
+		rect := chart.ContentRect(false)
+		gc.DrawRect(rect, unison.ThemeAboveSurface.Paint(gc, rect, paintstyle.Fill))
+		font := unison.LabelFont.Face().Font(toolbarFontSize)
+		decoration := &unison.TextDecoration{Font: font, OnBackgroundInk: unison.ThemeOnSurface}
+		caption := &unison.TextDecoration{Font: unison.LabelFont, OnBackgroundInk: unison.ThemeOnSurface}
+		text := unison.NewText(group.Title, caption)
+		text.Draw(gc, rect.X+4, rect.Y+text.Baseline()+2)
+		barSpace := rect.Width - chartLabelWidth - chartCountWidth - 8
+		for i, e := range entries {
+			y := rect.Y + chartBarHeight*float32(i+1) + chartBarHeight/2
+			label := unison.NewText(e.Name, decoration)
+			label.Draw(gc, rect.X+4, y+label.Baseline()/2)
+			bar := unison.NewRect(rect.X+chartLabelWidth, y-chartBarHeight/2+2,
+				barSpace*float32(e.Count)/float32(maxCount), chartBarHeight-4)
+			gc.DrawRect(bar, unison.ThemeFocus.Paint(gc, bar, paintstyle.Fill))
+			count := unison.NewText(strconv.Itoa(e.Count), decoration)
+			count.Draw(gc, bar.Right()+4, y+count.Baseline()/2)
+		}
+	}
+	return chart
+}
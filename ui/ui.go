@@ -9,6 +9,7 @@ package ui
 import (
 	"Emby_Explorer/api"
 	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
 	"Emby_Explorer/settings"
 	"github.com/richardwilkes/unison"
 )
@@ -35,11 +36,13 @@ func NewMainWindow() error {
 		VSpacing: 5,
 	})
 	content.AddChild(createToolbarPanel())
-	content.AddChild(createTablePanel())
+	content.AddChild(createBodyPanel())
 	installDefaultMenus(mainWindow)
 	installCallbacks()
 	_ = LoadPreferences()
 	prefs := settings.GetPreferences()
+	api.SetPeopleLimits(prefs.PeopleLimits)
+	showFacetSidebar(prefs.ShowFacets)
 	rect := prefs.WindowRect
 	if rect.Width < wndMinWidth {
 		rect.Width = wndMinWidth
@@ -64,6 +67,9 @@ func NewMainWindow() error {
 		api.InitApiPreferences(prefs.EmbySecure, prefs.EmbyServer, prefs.EmbyPort, prefs.EmbyUser, string(prefs.EmbyPassword))
 	}
 	setFunctions(true, v, false, false, false)
+	if v {
+		loadCachedViews()
+	}
 	mainWindow.ToFront()
 	return nil
 }
@@ -71,6 +77,7 @@ func NewMainWindow() error {
 func installDefaultMenus(wnd *unison.Window) {
 	unison.DefaultMenuFactory().BarForWindow(wnd, func(m unison.Menu) {
 		unison.InsertStdMenus(m, AboutDialog, PreferencesDialogFromMenu, nil)
+		insertAppMenus(m)
 	})
 }
 
@@ -78,6 +85,22 @@ func installCallbacks() {
 	viewsPopupMenu.SelectionChangedCallback = func(popup *unison.PopupMenu[string]) {
 		switchView()
 	}
+	watchPopupMenu.SelectionChangedCallback = func(popup *unison.PopupMenu[string]) {
+		watchFilter = models.WatchFilter(popup.SelectedIndex())
+		applyFilters()
+	}
+	qualityPopupMenu.SelectionChangedCallback = func(popup *unison.PopupMenu[string]) {
+		if index := popup.SelectedIndex(); index >= 0 && index < len(qualityFilters) {
+			qualityFilter = qualityFilters[index]
+			applyFilters()
+		}
+	}
+	languagePopupMenu.SelectionChangedCallback = func(popup *unison.PopupMenu[string]) {
+		if index := popup.SelectedIndex(); index >= 0 && index < len(languageFilters) {
+			languageFilter = languageFilters[index]
+			applyFilters()
+		}
+	}
 	mainWindow.MinMaxContentSizeCallback = func() (minSize, maxSize unison.Size) {
 		return windowMinMaxResizeCallback()
 	}
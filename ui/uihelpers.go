@@ -10,12 +10,15 @@ package ui
 import (
 	"Emby_Explorer/api"
 	"Emby_Explorer/assets"
+	"Emby_Explorer/export"
 	"Emby_Explorer/models"
+	"Emby_Explorer/settings"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/behavior"
 	"github.com/richardwilkes/unison/enums/paintstyle"
+	"time"
 )
 
 const (
@@ -26,20 +29,33 @@ const (
 	viewsPopupHeight         = 20
 	coverMaxWidth            = "300"
 	coverMaxHeight           = "300"
+	thumbMaxWidth            = "150"
+	thumbMaxHeight           = "150"
+	thumbCellWidth   float32 = 160
+	thumbCellHeight  float32 = 150
+	thumbWorkers             = 4 // thumbnails loaded at the same time
 )
 
 var viewsPopupMenu *unison.PopupMenu[string]
+var watchPopupMenu *unison.PopupMenu[string]
+var languagePopupMenu *unison.PopupMenu[string]
+var qualityPopupMenu *unison.PopupMenu[string]
 var prefsBtn *unison.Button
 var authBtn *unison.Button
 var fetchBtn *unison.Button
 var detailsBtn *unison.Button
 var exportBtn *unison.Button
+var gridBtn *unison.Button
 
 var mainContent *unison.Panel
 var logoPanel *unison.Panel
 var tableScrollArea *unison.ScrollPanel
 var collectionType = ""
 var canDisplayDetails = false
+var photoGridMode = false
+
+// Closed when the photo grid is replaced, the thumbnails not loaded yet are dropped
+var thumbsDone chan struct{}
 
 func newSVGButton(svg *unison.SVG) *unison.Button {
 	btn := unison.NewButton()
@@ -129,7 +145,7 @@ func createToolbarPanel() *unison.Panel {
 		fetchBtn.SetEnabled(true)
 		fetchBtn.SetFocusable(false)
 		panel.AddChild(fetchBtn)
-		fetchBtn.ClickCallback = func() { embyFetchItemsForUser() }
+		fetchBtn.ClickCallback = func() { embyFetchItemsForUser(false) }
 	}
 	detailsBtn, err = createButton(assets.CapDetails, assets.IconDetails)
 	if err == nil {
@@ -145,6 +161,78 @@ func createToolbarPanel() *unison.Panel {
 		panel.AddChild(exportBtn)
 		exportBtn.ClickCallback = func() { embyExport() }
 	}
+	gridBtn, err = createButton(assets.CapGrid, assets.IconGrid)
+	if err == nil {
+		gridBtn.SetEnabled(false)
+		gridBtn.SetFocusable(false)
+		panel.AddChild(gridBtn)
+		gridBtn.ClickCallback = func() { togglePhotoGrid() }
+	}
+	createSpacer(25, panel)
+	lblShow := unison.NewLabel()
+	lblShow.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	lblShow.SetTitle(assets.CapShow)
+	lblShow.SetLayoutData(align.Middle)
+	panel.AddChild(lblShow)
+	createSpacer(5, panel)
+	watchPopupMenu = unison.NewPopupMenu[string]()
+	watchPopupMenu.SetLayoutData(align.Middle)
+	watchPopupMenu.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	watchPopupMenu.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+		minSize = viewsPopupSize
+		prefSize = viewsPopupSize
+		maxSize = viewsPopupSize
+		return
+	})
+	watchPopupMenu.SetFocusable(false)
+	// Order matches models.WatchFilter
+	watchPopupMenu.AddItem(assets.CapFilterAll, assets.CapFilterUnplayed, assets.CapFilterInProgress, assets.CapFilterFavorites)
+	watchPopupMenu.SelectIndex(int(models.WatchAll))
+	panel.AddChild(watchPopupMenu)
+	createSpacer(25, panel)
+	lblMissing := unison.NewLabel()
+	lblMissing.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	lblMissing.SetTitle(assets.CapMissing)
+	lblMissing.SetLayoutData(align.Middle)
+	panel.AddChild(lblMissing)
+	createSpacer(5, panel)
+	languagePopupMenu = unison.NewPopupMenu[string]()
+	languagePopupMenu.SetLayoutData(align.Middle)
+	languagePopupMenu.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	languagePopupMenu.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+		minSize = viewsPopupSize
+		prefSize = viewsPopupSize
+		maxSize = viewsPopupSize
+		return
+	})
+	languagePopupMenu.SetFocusable(false)
+	languagePopupMenu.AddItem(assets.CapFilterNone)
+	languagePopupMenu.SelectIndex(0)
+	languagePopupMenu.SetEnabled(false)
+	panel.AddChild(languagePopupMenu)
+	createSpacer(25, panel)
+	lblQuality := unison.NewLabel()
+	lblQuality.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	lblQuality.SetTitle(assets.CapQuality)
+	lblQuality.SetLayoutData(align.Middle)
+	panel.AddChild(lblQuality)
+	createSpacer(5, panel)
+	qualityPopupMenu = unison.NewPopupMenu[string]()
+	qualityPopupMenu.SetLayoutData(align.Middle)
+	qualityPopupMenu.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+	qualityPopupMenu.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+		minSize = viewsPopupSize
+		prefSize = viewsPopupSize
+		maxSize = viewsPopupSize
+		return
+	})
+	qualityPopupMenu.SetFocusable(false)
+	for _, f := range qualityFilters {
+		qualityPopupMenu.AddItem(qualityFilterCaption(f))
+	}
+	qualityPopupMenu.SelectIndex(0)
+	qualityPopupMenu.SetEnabled(false)
+	panel.AddChild(qualityPopupMenu)
 	return panel
 }
 
@@ -192,26 +280,65 @@ func createEmbyLogoPanel() *unison.Panel {
 }
 
 func setLogoPanel() {
+	stopThumbnails()
 	if logoPanel != nil {
 		mainContent.RemoveAllChildren()
 		mainContent.AddChild(logoPanel)
 	}
 }
 
+// The latest snapshot of the view is shown until the view is fetched; before authentication only
+// the snapshots can be browsed
 func switchView() {
-	collectionType = api.AllowedCollectionTypes[viewsPopupMenu.SelectedIndex()]
-	setFunctions(false, false, true, false, false)
+	view := userViews[viewsPopupMenu.SelectedIndex()]
+	collectionType = view.CollectionType
+	models.ChangedItems = make(map[string]bool)
+	online := api.EmbySession.AccessToken != ""
+	setFunctions(!online, !online && settings.Valid(), online, false, false)
+	gridBtn.SetEnabled(false)
+	if showSnapshot(view) {
+		return
+	}
+	cacheItems(nil)
+	setSnapshotTitle(time.Time{})
 	setLogoPanel()
 }
 
+// Displayed columns of a table, followed by the optional watch state columns
+func tableColumns(desc models.TableDescription) []models.ColumnDescription {
+	columns := make([]models.ColumnDescription, 0)
+	columns = append(columns, desc.Columns[:desc.NoOfColumns]...)
+	if settings.GetShowUserData() {
+		for _, c := range models.UserDataColumns {
+			c.XLSColumn = export.ColumnName(len(columns) + 1)
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+func newColumnInfo(count int) []unison.ColumnInfo {
+	info := make([]unison.ColumnInfo, count)
+	for i := range info {
+		info[i].ID = i
+		info[i].Minimum = 20
+		info[i].Maximum = 10000
+	}
+	return info
+}
+
+func newColumnHeaders[T unison.TableRowConstraint[T]](columns []models.ColumnDescription) []unison.TableColumnHeader[T] {
+	headers := make([]unison.TableColumnHeader[T], 0)
+	for _, c := range columns {
+		headers = append(headers, unison.NewTableColumnHeader[T](c.Caption, ""))
+	}
+	return headers
+}
+
 func newMovieTable(content *unison.Panel, movieData []models.MovieData) {
 	models.MovieTable = unison.NewTable[*models.MovieRow](&unison.SimpleTableModel[*models.MovieRow]{})
-	models.MovieTable.Columns = make([]unison.ColumnInfo, models.MovieTableDescription.NoOfColumns)
-	for i := range models.MovieTable.Columns {
-		models.MovieTable.Columns[i].ID = i
-		models.MovieTable.Columns[i].Minimum = 20
-		models.MovieTable.Columns[i].Maximum = 10000
-	}
+	columns := tableColumns(models.MovieTableDescription)
+	models.MovieTable.Columns = newColumnInfo(len(columns))
 	rows := make([]*models.MovieRow, 0)
 	for _, m := range movieData {
 		r := models.NewMovieRow(tid.MustNewTID('a'), m)
@@ -219,26 +346,14 @@ func newMovieTable(content *unison.Panel, movieData []models.MovieData) {
 	}
 	models.MovieTable.SetRootRows(rows)
 	models.MovieTable.SizeColumnsToFit(true)
-	header := unison.NewTableHeader[*models.MovieRow](models.MovieTable,
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[0].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[1].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[2].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[3].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[4].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[5].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[6].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[7].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[8].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[9].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[10].Caption, ""),
-		unison.NewTableColumnHeader[*models.MovieRow](models.MovieTableDescription.Columns[11].Caption, ""),
-	)
+	header := unison.NewTableHeader[*models.MovieRow](models.MovieTable, newColumnHeaders[*models.MovieRow](columns)...)
 	header.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
 		VAlign: align.Fill,
 		HGrab:  true,
 	})
 	tableScrollArea = unison.NewScrollPanel()
+	installContextMenu(models.MovieTable)
 	tableScrollArea.SetContent(models.MovieTable, behavior.Fill, behavior.Fill)
 	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
@@ -257,12 +372,8 @@ func newMovieTable(content *unison.Panel, movieData []models.MovieData) {
 
 func newTVShowTable(content *unison.Panel, tvshowData []models.TVShowData) {
 	models.TVShowTable = unison.NewTable[*models.TVShowRow](&unison.SimpleTableModel[*models.TVShowRow]{})
-	models.TVShowTable.Columns = make([]unison.ColumnInfo, models.TVShowTableDescription.NoOfColumns)
-	for i := range models.TVShowTable.Columns {
-		models.TVShowTable.Columns[i].ID = i
-		models.TVShowTable.Columns[i].Minimum = 20
-		models.TVShowTable.Columns[i].Maximum = 10000
-	}
+	columns := tableColumns(models.TVShowTableDescription)
+	models.TVShowTable.Columns = newColumnInfo(len(columns))
 	rows := make([]*models.TVShowRow, 0)
 	for _, m := range tvshowData {
 		r := models.NewTVShowRow(tid.MustNewTID('a'), m)
@@ -270,26 +381,14 @@ func newTVShowTable(content *unison.Panel, tvshowData []models.TVShowData) {
 	}
 	models.TVShowTable.SetRootRows(rows)
 	models.TVShowTable.SizeColumnsToFit(true)
-	header := unison.NewTableHeader[*models.TVShowRow](models.TVShowTable,
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[0].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[1].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[2].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[3].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[4].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[5].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[6].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[7].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[8].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[9].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[10].Caption, ""),
-		unison.NewTableColumnHeader[*models.TVShowRow](models.TVShowTableDescription.Columns[11].Caption, ""),
-	)
+	header := unison.NewTableHeader[*models.TVShowRow](models.TVShowTable, newColumnHeaders[*models.TVShowRow](columns)...)
 	header.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
 		VAlign: align.Fill,
 		HGrab:  true,
 	})
 	tableScrollArea = unison.NewScrollPanel()
+	installContextMenu(models.TVShowTable)
 	tableScrollArea.SetContent(models.TVShowTable, behavior.Fill, behavior.Fill)
 	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
@@ -308,12 +407,8 @@ func newTVShowTable(content *unison.Panel, tvshowData []models.TVShowData) {
 
 func newHomeVideoTable(content *unison.Panel, homevideoData []models.HomeVideoData) {
 	models.HomeVideoTable = unison.NewTable[*models.HomeVideoRow](&unison.SimpleTableModel[*models.HomeVideoRow]{})
-	models.HomeVideoTable.Columns = make([]unison.ColumnInfo, models.HomeVideoTableDescription.NoOfColumns)
-	for i := range models.HomeVideoTable.Columns {
-		models.HomeVideoTable.Columns[i].ID = i
-		models.HomeVideoTable.Columns[i].Minimum = 20
-		models.HomeVideoTable.Columns[i].Maximum = 10000
-	}
+	columns := tableColumns(models.HomeVideoTableDescription)
+	models.HomeVideoTable.Columns = newColumnInfo(len(columns))
 	rows := make([]*models.HomeVideoRow, 0)
 	for _, m := range homevideoData {
 		r := models.NewHomeVideoRow(tid.MustNewTID('a'), m)
@@ -321,21 +416,14 @@ func newHomeVideoTable(content *unison.Panel, homevideoData []models.HomeVideoDa
 	}
 	models.HomeVideoTable.SetRootRows(rows)
 	models.HomeVideoTable.SizeColumnsToFit(true)
-	header := unison.NewTableHeader[*models.HomeVideoRow](models.HomeVideoTable,
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[0].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[1].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[2].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[3].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[4].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[5].Caption, ""),
-		unison.NewTableColumnHeader[*models.HomeVideoRow](models.HomeVideoTableDescription.Columns[6].Caption, ""),
-	)
+	header := unison.NewTableHeader[*models.HomeVideoRow](models.HomeVideoTable, newColumnHeaders[*models.HomeVideoRow](columns)...)
 	header.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
 		VAlign: align.Fill,
 		HGrab:  true,
 	})
 	tableScrollArea = unison.NewScrollPanel()
+	installContextMenu(models.HomeVideoTable)
 	tableScrollArea.SetContent(models.HomeVideoTable, behavior.Fill, behavior.Fill)
 	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
@@ -344,9 +432,192 @@ func newHomeVideoTable(content *unison.Panel, homevideoData []models.HomeVideoDa
 		VGrab:  true,
 	})
 	tableScrollArea.SetColumnHeader(header)
+	models.HomeVideoTable.SelectionChangedCallback = func() {
+		if canDisplayDetails {
+			detailsWindowDisplay()
+		}
+	}
+	content.AddChild(tableScrollArea)
+}
+
+func newPhotoTable(content *unison.Panel, photoData []models.PhotoData) {
+	models.PhotoTable = unison.NewTable[*models.PhotoRow](&unison.SimpleTableModel[*models.PhotoRow]{})
+	columns := tableColumns(models.PhotoTableDescription)
+	models.PhotoTable.Columns = newColumnInfo(len(columns))
+	// Build folder tree, photos & folders without a known parent folder go to the top level
+	folders := make(map[string]*models.PhotoRow)
+	all := make([]*models.PhotoRow, 0)
+	for _, m := range photoData {
+		r := models.NewPhotoRow(tid.MustNewTID('a'), m)
+		if m.IsFolder {
+			folders[m.FolderId] = r
+		}
+		all = append(all, r)
+	}
+	rows := make([]*models.PhotoRow, 0)
+	for _, r := range all {
+		if f, ok := folders[r.M.ParentId]; ok {
+			f.AddChild(r)
+		} else {
+			rows = append(rows, r)
+		}
+	}
+	models.PhotoTable.SetRootRows(rows)
+	models.PhotoTable.SizeColumnsToFit(true)
+	header := unison.NewTableHeader[*models.PhotoRow](models.PhotoTable, newColumnHeaders[*models.PhotoRow](columns)...)
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+	})
+	tableScrollArea = unison.NewScrollPanel()
+	installContextMenu(models.PhotoTable)
+	tableScrollArea.SetContent(models.PhotoTable, behavior.Fill, behavior.Fill)
+	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	tableScrollArea.SetColumnHeader(header)
+	models.PhotoTable.SelectionChangedCallback = func() {
+		if canDisplayDetails {
+			detailsWindowDisplay()
+		}
+	}
+	content.AddChild(tableScrollArea)
+}
+
+func newLiveTvTable(content *unison.Panel, liveTvData []models.LiveTvData) {
+	models.LiveTvTable = unison.NewTable[*models.LiveTvRow](&unison.SimpleTableModel[*models.LiveTvRow]{})
+	columns := tableColumns(models.LiveTvTableDescription)
+	models.LiveTvTable.Columns = newColumnInfo(len(columns))
+	rows := make([]*models.LiveTvRow, 0)
+	for _, m := range liveTvData {
+		r := models.NewLiveTvRow(tid.MustNewTID('a'), m)
+		rows = append(rows, r)
+	}
+	models.LiveTvTable.SetRootRows(rows)
+	models.LiveTvTable.SizeColumnsToFit(true)
+	header := unison.NewTableHeader[*models.LiveTvRow](models.LiveTvTable, newColumnHeaders[*models.LiveTvRow](columns)...)
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+	})
+	tableScrollArea = unison.NewScrollPanel()
+	installContextMenu(models.LiveTvTable)
+	tableScrollArea.SetContent(models.LiveTvTable, behavior.Fill, behavior.Fill)
+	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	tableScrollArea.SetColumnHeader(header)
+	models.LiveTvTable.SelectionChangedCallback = func() {
+		if canDisplayDetails {
+			detailsWindowDisplay()
+		}
+	}
 	content.AddChild(tableScrollArea)
 }
 
+// Thumbnail grid, images are loaded in the background and drawn as soon as they arrive
+func newPhotoGrid(content *unison.Panel, photoData []models.PhotoData) {
+	grid := unison.NewPanel()
+	grid.SetLayout(&unison.FlowLayout{
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	grid.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(5)))
+	cellSize := unison.NewSize(thumbCellWidth, thumbCellHeight)
+	thumbs := make([]thumbJob, 0, len(photoData))
+	for _, p := range photoData {
+		if p.IsFolder {
+			continue
+		}
+		cell := unison.NewPanel()
+		cell.SetLayout(&unison.FlexLayout{Columns: 1})
+		thumb := unison.NewLabel()
+		thumb.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+			return cellSize, cellSize, cellSize
+		})
+		thumb.SetLayoutData(align.Middle)
+		caption := unison.NewLabel()
+		caption.Font = unison.LabelFont.Face().Font(toolbarFontSize)
+		caption.SetTitle(p.Name)
+		caption.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+		cell.AddChild(thumb)
+		cell.AddChild(caption)
+		grid.AddChild(cell)
+		thumbs = append(thumbs, thumbJob{itemid: p.PhotoId, label: thumb})
+	}
+	loadThumbnails(thumbs)
+	tableScrollArea = unison.NewScrollPanel()
+	tableScrollArea.SetContent(grid, behavior.Fill, behavior.Unmodified)
+	tableScrollArea.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	content.AddChild(tableScrollArea)
+}
+
+type thumbJob struct {
+	itemid string
+	label  *unison.Label
+}
+
+// A few workers load the thumbnails in grid order, until the grid is replaced (see stopThumbnails)
+func loadThumbnails(thumbs []thumbJob) {
+	stopThumbnails()
+	done := make(chan struct{})
+	thumbsDone = done
+	jobs := make(chan thumbJob)
+	go func() {
+		defer close(jobs)
+		for _, j := range thumbs {
+			select {
+			case jobs <- j:
+			case <-done:
+				return
+			}
+		}
+	}()
+	for i := 0; i < thumbWorkers; i++ {
+		go func() {
+			for j := range jobs {
+				img, err := newThumbnailFromBytes(j.itemid)
+				if err != nil || img == nil {
+					continue
+				}
+				unison.InvokeTask(func() {
+					select {
+					case <-done:
+					default:
+						j.label.Drawable = img
+						j.label.MarkForRedraw()
+					}
+				})
+			}
+		}()
+	}
+}
+
+func stopThumbnails() {
+	if thumbsDone != nil {
+		close(thumbsDone)
+		thumbsDone = nil
+	}
+}
+
+func togglePhotoGrid() {
+	photoGridMode = !photoGridMode
+	showTable()
+}
+
 func newImageFromBytes(itemid string) (*unison.Image, error) {
 	var newImage *unison.Image
 	image, err := api.GetPrimaryImageForItemInt(itemid, api.ImageFormatPng, coverMaxWidth, coverMaxHeight)
@@ -356,3 +627,11 @@ func newImageFromBytes(itemid string) (*unison.Image, error) {
 	newImage, err = unison.NewImageFromBytes(image, 1)
 	return newImage, nil
 }
+
+func newThumbnailFromBytes(itemid string) (*unison.Image, error) {
+	image, err := api.GetPrimaryImageForItemInt(itemid, api.ImageFormatPng, thumbMaxWidth, thumbMaxHeight)
+	if err != nil {
+		return nil, err
+	}
+	return unison.NewImageFromBytes(image, 1)
+}
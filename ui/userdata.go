@@ -0,0 +1,247 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Watch state actions on selected rows: played/unplayed and favourites
+// Rows are updated at once and rolled back if the server rejects the change
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+)
+
+type userDataAction int
+
+const (
+	actionMarkPlayed userDataAction = iota
+	actionMarkUnplayed
+	actionAddFavorite
+	actionRemoveFavorite
+)
+
+// A selected item, both the row's copy and the data table's copy of its user data are kept in sync
+type userDataTarget struct {
+	itemId string
+	row    *models.UserData
+	data   *models.UserData
+}
+
+func userDataTargets[R any, D any](rows []R, table []D, rowData func(R) *D, id func(*D) string,
+	userData func(*D) *models.UserData) []userDataTarget {
+	index := make(map[string]int)
+	for i := range table {
+		index[id(&table[i])] = i
+	}
+	result := make([]userDataTarget, 0)
+	for _, r := range rows {
+		d := rowData(r)
+		t := userDataTarget{itemId: id(d), row: userData(d)}
+		if t.itemId == "" {
+			continue
+		}
+		if i, ok := index[t.itemId]; ok {
+			t.data = userData(&table[i])
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+func selectedUserDataTargets() []userDataTarget {
+	if tableScrollArea == nil || tableScrollArea.Parent() == nil {
+		return nil
+	}
+	switch collectionType {
+	case api.CollectionMovies:
+		return userDataTargets(models.MovieTable.SelectedRows(false), models.MovieDataTable,
+			func(r *models.MovieRow) *models.MovieData { return &r.M },
+			func(d *models.MovieData) string { return d.MovieId },
+			func(d *models.MovieData) *models.UserData { return &d.UserData })
+	case api.CollectionTVShows:
+		return userDataTargets(models.TVShowTable.SelectedRows(false), models.TVShowDataTable,
+			func(r *models.TVShowRow) *models.TVShowData { return &r.M },
+			tvShowItemId,
+			func(d *models.TVShowData) *models.UserData { return &d.UserData })
+	case api.CollectionHomeVideos:
+		return userDataTargets(models.HomeVideoTable.SelectedRows(false), models.HomeVideoDataTable,
+			func(r *models.HomeVideoRow) *models.HomeVideoData { return &r.M },
+			func(d *models.HomeVideoData) string { return d.VideoId },
+			func(d *models.HomeVideoData) *models.UserData { return &d.UserData })
+	case api.CollectionPhotos:
+		if photoGridMode {
+			return nil
+		}
+		return userDataTargets(models.PhotoTable.SelectedRows(false), models.PhotoDataTable,
+			func(r *models.PhotoRow) *models.PhotoData { return &r.M },
+			func(d *models.PhotoData) string {
+				if d.IsFolder {
+					return d.FolderId
+				}
+				return d.PhotoId
+			},
+			func(d *models.PhotoData) *models.UserData { return &d.UserData })
+	case api.CollectionLiveTvChannels:
+		// the user data of a channel belongs to the channel, not to the programme currently airing
+		return userDataTargets(models.LiveTvTable.SelectedRows(false), models.LiveTvDataTable,
+			func(r *models.LiveTvRow) *models.LiveTvData { return &r.M },
+			func(d *models.LiveTvData) string { return d.ChannelId },
+			func(d *models.LiveTvData) *models.UserData { return &d.UserData })
+	case api.CollectionLiveTvGuide, api.CollectionLiveTvRecordings:
+		return userDataTargets(models.LiveTvTable.SelectedRows(false), models.LiveTvDataTable,
+			func(r *models.LiveTvRow) *models.LiveTvData { return &r.M },
+			func(d *models.LiveTvData) string { return d.ItemId },
+			func(d *models.LiveTvData) *models.UserData { return &d.UserData })
+	default:
+		return nil
+	}
+}
+
+func tvShowItemId(d *models.TVShowData) string {
+	switch d.Type_ {
+	case api.SeriesType:
+		return d.SeriesId
+	case api.SeasonType:
+		return d.SeasonId
+	default:
+		return d.EpisodeId
+	}
+}
+
+func canChangeUserData() bool {
+	return len(selectedUserDataTargets()) > 0
+}
+
+func changeUserData(action userDataAction) {
+	targets := selectedUserDataTargets()
+	if len(targets) == 0 {
+		return
+	}
+	previous := make([]models.UserData, len(targets))
+	for i, t := range targets {
+		previous[i] = *t.row
+		t.set(func(u *models.UserData) { applyUserDataAction(u, action) })
+	}
+	redrawTable()
+	go func() {
+		var failed error
+		for i, t := range targets {
+			var result api.UserItemDataDto
+			var err error
+			switch action {
+			case actionMarkPlayed, actionMarkUnplayed:
+				result, err = api.UserSetPlayedInt(t.itemId, action == actionMarkPlayed)
+			case actionAddFavorite, actionRemoveFavorite:
+				result, err = api.UserSetFavoriteInt(t.itemId, action == actionAddFavorite)
+			default:
+			}
+			unison.InvokeTask(func() {
+				if err != nil {
+					t.set(func(u *models.UserData) { *u = previous[i] })
+				} else if result.ItemId != "" {
+					if action == actionMarkPlayed || action == actionMarkUnplayed {
+						// play count & last played date are only known to the server
+						t.set(func(u *models.UserData) { *u = api.GetUserDataDisplayData(result) })
+					}
+					// the items fetched are converted again e.g. after an edit, they must not be outdated
+					if d, ok := itemCache[t.itemId]; ok {
+						d.UserData = &result
+						updateCachedItem(d)
+					}
+				}
+			})
+			if err != nil && failed == nil {
+				failed = err
+			}
+		}
+		unison.InvokeTask(func() {
+			redrawTable()
+			if failed != nil {
+				DialogToDisplaySystemError(assets.ErrUserDataFailed, failed)
+			}
+		})
+	}()
+}
+
+func (t userDataTarget) set(change func(u *models.UserData)) {
+	change(t.row)
+	if t.data != nil {
+		change(t.data)
+	}
+}
+
+func applyUserDataAction(u *models.UserData, action userDataAction) {
+	switch action {
+	case actionMarkPlayed:
+		u.SetPlayed(true)
+	case actionMarkUnplayed:
+		u.SetPlayed(false)
+	case actionAddFavorite:
+		u.SetFavorite(true)
+	case actionRemoveFavorite:
+		u.SetFavorite(false)
+	default:
+	}
+}
+
+func redrawTable() {
+	if tableScrollArea != nil {
+		tableScrollArea.MarkForRedraw()
+		if content := tableScrollArea.Content(); content != nil {
+			content.AsPanel().MarkForRedraw()
+		}
+	}
+}
+
+// Right click selects the row under the mouse (unless it is part of the selection already) and opens the item menu,
+// double click edits the cell (see editCell)
+func installContextMenu[T unison.TableRowConstraint[T]](table *unison.Table[T]) {
+	table.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if button == unison.ButtonLeft && clickCount == 2 {
+			stop := table.DefaultMouseDown(where, button, clickCount, mod)
+			return editCell(table, where) || stop
+		}
+		if button != unison.ButtonRight || clickCount != 1 {
+			return table.DefaultMouseDown(where, button, clickCount, mod)
+		}
+		table.RequestFocus()
+		if index := table.OverRow(where.Y); index >= 0 && !table.IsRowOrAnyParentSelected(index) {
+			table.SelectByIndex(index)
+		}
+		if !canChangeUserData() {
+			return true
+		}
+		f := unison.DefaultMenuFactory()
+		cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+		for _, entry := range userDataMenuEntries {
+			action := entry.action
+			cm.InsertItem(-1, f.NewItem(-1, entry.title, unison.KeyBinding{}, nil, func(unison.MenuItem) {
+				changeUserData(action)
+			}))
+		}
+		if canEditMetadata() {
+			cm.InsertSeparator(-1, false)
+			cm.InsertItem(-1, f.NewItem(-1, assets.CapEditMetadata, unison.KeyBinding{}, nil, func(unison.MenuItem) {
+				editMetadataDialog()
+			}))
+		}
+		if canRefreshMetadata() {
+			cm.InsertItem(-1, f.NewItem(-1, assets.CapRefreshMetadata, unison.KeyBinding{}, nil, func(unison.MenuItem) {
+				refreshMetadataDialog()
+			}))
+		}
+		if canShowItemAccess() {
+			cm.InsertItem(-1, f.NewItem(-1, assets.CapItemAccess, unison.KeyBinding{}, nil, func(unison.MenuItem) {
+				itemAccessDisplay()
+			}))
+		}
+		cm.Popup(unison.Rect{
+			Point: table.PointToRoot(where),
+			Size:  unison.Size{Width: 1, Height: 1},
+		}, 0)
+		cm.Dispose()
+		return true
+	}
+}
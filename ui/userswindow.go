@@ -0,0 +1,82 @@
+// ---------------------------------------------------------------------------------------------------------------------
+// (w) 2024 by Jan Buchholz
+// Users window (administrators only): every user of the server with access rights, last login & activity
+// Using Unison library (c) Richard A. Wilkes
+// https://github.com/richardwilkes/unison
+// ---------------------------------------------------------------------------------------------------------------------
+
+package ui
+
+import (
+	"Emby_Explorer/api"
+	"Emby_Explorer/assets"
+	"Emby_Explorer/models"
+	"github.com/richardwilkes/unison"
+	"strconv"
+)
+
+const (
+	usersWindowWidth  float32 = 1400
+	usersWindowHeight float32 = 500
+)
+
+var usersWindow *unison.Window
+var usersData []models.ListData
+
+func usersWindowDisplay() {
+	if usersWindow != nil {
+		usersWindow.ToFront()
+		return
+	}
+	wnd, err := newListWindow(assets.CapUsers, usersWindowWidth, usersWindowHeight)
+	if err != nil {
+		DialogToDisplaySystemError(assets.CapError, err)
+		return
+	}
+	usersWindow = wnd
+	usersData = nil
+	table, scrollArea := newListTable(models.UserTableDescription, usersData)
+	toolbar, status := newListToolbar()
+	var refreshBtn *unison.Button
+	fetch := func() {
+		refreshBtn.SetEnabled(false)
+		status.SetTitle(assets.CapSearching)
+		go fetchUsers(wnd, table, status, refreshBtn)
+	}
+	refreshBtn = addListButton(toolbar, assets.CapRefresh, assets.IconFetch, fetch)
+	addListButton(toolbar, assets.CapExport, assets.IconExport, func() {
+		exportList(models.UserTableDescription, usersData, assets.CapUsers)
+	})
+	content := wnd.Content()
+	content.AddChild(toolbar)
+	content.AddChild(scrollArea)
+	wnd.WillCloseCallback = func() {
+		usersWindow = nil
+	}
+	wnd.ToFront()
+	fetch()
+}
+
+// Runs in the background; without the libraries & ratings, ids & rating values are shown instead of names
+func fetchUsers(wnd *unison.Window, table *unison.Table[*models.ListRow], status *unison.Label,
+	refreshBtn *unison.Button) {
+	users, err := api.GetUsersInt()
+	folders, _ := api.GetVirtualFoldersInt()
+	ratings, _ := api.GetParentalRatingsInt()
+	data := api.GetUserDisplayData(users, api.LibraryNames(folders), ratings)
+	unison.InvokeTask(func() {
+		if usersWindow != wnd {
+			return
+		}
+		refreshBtn.SetEnabled(true)
+		if err != nil {
+			status.SetTitle(assets.ErrFetchUsers + " " + err.Error())
+		} else {
+			usersData = data
+			setListRows(table, usersData)
+			status.SetTitle(assets.CapUsers + ": " + strconv.Itoa(len(usersData)))
+			table.MarkForRedraw()
+		}
+		status.Parent().MarkForLayoutAndRedraw()
+	})
+}